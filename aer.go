@@ -0,0 +1,109 @@
+package nvector
+
+import (
+	"math"
+)
+
+// AER is a position relative to an observer, expressed as azimuth, elevation,
+// and slant range (look angles).
+type AER struct {
+	// Azimuth is the direction in radians in the horizontal plane, measured
+	// clockwise from north, in the range [0, 2pi).
+	Azimuth float64
+	// Elevation is the angle in radians above the horizontal plane, in the range
+	// [-pi/2, pi/2].
+	Elevation float64
+	// Range is the straight-line distance in meters.
+	Range float64
+}
+
+// ToAER finds the azimuth, elevation, and slant range from an observer
+// position to a target position.
+//
+// The horizontal plane and north are defined by the observer's North-East-Down
+// frame N. When the target is directly above or below the observer, the
+// azimuth is undefined, and 0 is returned.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func ToAER(from Position, to Position, e Ellipsoid, f Matrix) AER {
+	return nedToAER(
+		Delta(from, to, e, f).Transform(ToRotationMatrix(from.Vector, f).Transpose()),
+	)
+}
+
+// ToAERBatch finds the azimuth, elevation, and slant range from an observer
+// position to each of many target positions, as described by ToAER.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func ToAERBatch(from Position, to []Position, e Ellipsoid, f Matrix) []AER {
+	origin := ToECEF(from, e, f)
+	r := ToRotationMatrix(from.Vector, f).Transpose()
+
+	aers := make([]AER, len(to))
+	for i, p := range to {
+		aers[i] = nedToAER(ToECEF(p, e, f).Sub(origin).Transform(r))
+	}
+
+	return aers
+}
+
+// ECEFToAER finds the azimuth, elevation, and slant range from an observer
+// position to a target ECEF position vector, as described by ToAER.
+//
+// f is the coordinate frame in which the vectors are decomposed.
+func ECEFToAER(from Position, p Vector, e Ellipsoid, f Matrix) AER {
+	return nedToAER(ECEFToNED(p, from, e, f))
+}
+
+// FromAER finds the target position at an azimuth, elevation, and slant range
+// from an observer position. It is the inverse of ToAER.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func FromAER(from Position, aer AER, e Ellipsoid, f Matrix) Position {
+	return DestinationNED(from, aerToNED(aer), e, f)
+}
+
+// AERToECEF finds the ECEF position vector at an azimuth, elevation, and slant
+// range from an observer position. It is the inverse of ECEFToAER.
+//
+// f is the coordinate frame in which the vectors are decomposed.
+func AERToECEF(from Position, aer AER, e Ellipsoid, f Matrix) Vector {
+	return NEDToECEF(aerToNED(aer), from, e, f)
+}
+
+// nedToAER converts a delta position vector decomposed in N to azimuth,
+// elevation, and slant range.
+func nedToAER(d Vector) AER {
+	horizontal := math.Hypot(d.X, d.Y)
+
+	var azimuth float64
+	if horizontal > 0 {
+		azimuth = math.Atan2(d.Y, d.X)
+		if azimuth < 0 {
+			azimuth += 2 * math.Pi
+		}
+		if azimuth == 2*math.Pi {
+			// a tiny negative azimuth rounds to 2π, which is equivalent to 0
+			azimuth = 0
+		}
+	}
+
+	return AER{
+		Azimuth:   azimuth,
+		Elevation: math.Atan2(-d.Z, horizontal),
+		Range:     d.Norm(),
+	}
+}
+
+// aerToNED converts azimuth, elevation, and slant range to a delta position
+// vector decomposed in N.
+func aerToNED(aer AER) Vector {
+	sinAz, cosAz := math.Sincos(aer.Azimuth)
+	sinEl, cosEl := math.Sincos(aer.Elevation)
+
+	return Vector{
+		aer.Range * cosEl * cosAz,
+		aer.Range * cosEl * sinAz,
+		-aer.Range * sinEl,
+	}
+}
@@ -0,0 +1,225 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ToAER(t *testing.T) {
+	t.Run("it matches example 1", func(t *testing.T) {
+		a := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(1), Longitude: Radians(2)},
+				ZAxisNorth,
+			),
+			Depth: 3,
+		}
+		b := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(4), Longitude: Radians(5)},
+				ZAxisNorth,
+			),
+			Depth: 6,
+		}
+		ned := Vector{X: 331730.23478089, Y: 332997.87498927, Z: 17404.27136194}
+
+		got := ToAER(a, b, WGS84, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToRadians(got.Azimuth, Radians(45.10926324), 1e-9); !eq {
+			equality.ReportInequality(t, "Azimuth", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(
+			got.Elevation,
+			math.Atan2(-ned.Z, math.Hypot(ned.X, ned.Y)),
+			1e-12,
+		); !eq {
+			equality.ReportInequality(t, "Elevation", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Range, ned.Norm(), 1e-7); !eq {
+			equality.ReportInequality(t, "Range", ineq)
+		}
+	})
+
+	t.Run("it returns azimuths in the range [0, 2pi)", func(t *testing.T) {
+		a := Position{
+			Vector: FromGeodeticCoordinates(GeodeticCoordinates{}, ZAxisNorth),
+		}
+		b := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(1), Longitude: Radians(-1)},
+				ZAxisNorth,
+			),
+		}
+
+		got := ToAER(a, b, WGS84, ZAxisNorth)
+
+		if got.Azimuth < 3*math.Pi/2 || got.Azimuth >= 2*math.Pi {
+			t.Errorf("got azimuth %v; want north-west", Degrees(got.Azimuth))
+		}
+	})
+
+	t.Run("it returns 0 rather than 2pi for tiny negative azimuths", func(t *testing.T) {
+		from := Position{
+			Vector: FromGeodeticCoordinates(GeodeticCoordinates{}, ZAxisNorth),
+		}
+		o := ToECEF(from, WGS84, ZAxisNorth)
+		// slightly west of due north
+		p := Vector{X: o.X, Y: o.Y - 1e-13, Z: o.Z + 1000}
+
+		got := ECEFToAER(from, p, WGS84, ZAxisNorth)
+
+		if got.Azimuth < 0 || got.Azimuth >= 2*math.Pi {
+			t.Errorf("got azimuth %v; want in the range [0, 2pi)", got.Azimuth)
+		}
+	})
+
+	t.Run("it handles the zenith and nadir", func(t *testing.T) {
+		tests := map[string]struct {
+			depth         float64
+			wantElevation float64
+		}{
+			"zenith": {-1000, math.Pi / 2},
+			"nadir":  {1000, -math.Pi / 2},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				v := Vector{X: 1, Y: 0, Z: 0}
+				from := Position{Vector: v}
+				to := Position{Vector: v, Depth: tt.depth}
+
+				got := ToAER(from, to, WGS84, XAxisNorth)
+
+				if got.Azimuth != 0 {
+					t.Errorf("got azimuth %v; want 0", got.Azimuth)
+				}
+				if eq, ineq := equality.EqualToRadians(got.Elevation, tt.wantElevation, 0); !eq {
+					equality.ReportInequality(t, "Elevation", ineq)
+				}
+				if eq, ineq := equality.EqualToFloat64(got.Range, 1000, 1e-9); !eq {
+					equality.ReportInequality(t, "Range", ineq)
+				}
+			})
+		}
+	})
+}
+
+func Test_ToAERBatch(t *testing.T) {
+	t.Run("it matches ToAER", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			from := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "fromNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "fromDepth"),
+			}
+			to := rapid.SliceOfN(
+				rapid.Custom(func(t *rapid.T) Position {
+					return Position{
+						Vector: rapidgen.UnitVector().Draw(t, "nVector"),
+						Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "depth"),
+					}
+				}),
+				0,
+				10,
+			).Draw(t, "to")
+
+			got := ToAERBatch(from, to, e, f)
+
+			if len(got) != len(to) {
+				t.Fatalf("got %d results; want %d", len(got), len(to))
+			}
+			for i, p := range to {
+				want := ToAER(from, p, e, f)
+
+				if eq, ineq := equality.EqualToRadians(got[i].Azimuth, want.Azimuth, 1e-12); !eq {
+					equality.ReportInequality(t, "Azimuth", ineq)
+				}
+				if eq, ineq := equality.EqualToRadians(got[i].Elevation, want.Elevation, 1e-12); !eq {
+					equality.ReportInequality(t, "Elevation", ineq)
+				}
+				if eq, ineq := equality.EqualToFloat64(got[i].Range, want.Range, 1e-6); !eq {
+					equality.ReportInequality(t, "Range", ineq)
+				}
+			}
+		})
+	})
+}
+
+func Test_ECEFToAER(t *testing.T) {
+	t.Run("it matches ToAER", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			from := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "fromNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "fromDepth"),
+			}
+			to := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "toNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "toDepth"),
+			}
+
+			want := ToAER(from, to, e, f)
+			got := ECEFToAER(from, ToECEF(to, e, f), e, f)
+
+			if eq, ineq := equality.EqualToRadians(got.Azimuth, want.Azimuth, 1e-12); !eq {
+				equality.ReportInequality(t, "Azimuth", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(got.Elevation, want.Elevation, 1e-12); !eq {
+				equality.ReportInequality(t, "Elevation", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Range, want.Range, 1e-6); !eq {
+				equality.ReportInequality(t, "Range", ineq)
+			}
+		})
+	})
+}
+
+func Test_FromAER(t *testing.T) {
+	t.Run("it is the inverse of ToAER", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			from := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "fromNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "fromDepth"),
+			}
+			to := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "toNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "toDepth"),
+			}
+
+			got := FromAER(from, ToAER(from, to, e, f), e, f)
+
+			if eq, ineq := equality.EqualToVectorWithDepth(got, to, 1e-12, 1e-6); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_AERToECEF(t *testing.T) {
+	t.Run("it is the inverse of ECEFToAER", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			from := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "fromNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "fromDepth"),
+			}
+			p := rapidgen.EcefVector(e).Draw(t, "p")
+
+			got := AERToECEF(from, ECEFToAER(from, p, e, f), e, f)
+
+			if eq, ineq := equality.EqualToVector(got, p, 1e-6); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package nvector
+
+import (
+	"math"
+)
+
+// rotationVectorThreshold is the norm of a quaternion's vector part below
+// which small-angle approximations are used when converting to and from
+// rotation vectors. Below this threshold, the truncated terms are smaller than
+// the float64 epsilon.
+const rotationVectorThreshold = 1e-8
+
+// AxisAngle is a rotation of Angle radians about a unit vector Axis, following
+// the right-hand rule.
+type AxisAngle struct {
+	Axis  Vector
+	Angle float64
+}
+
+// AxisAngleToRotationMatrix converts an axis-angle rotation to a rotation
+// matrix, using Rodrigues' rotation formula.
+func AxisAngleToRotationMatrix(a AxisAngle) Matrix {
+	s, c := math.Sincos(a.Angle)
+	t := 1 - c
+	x, y, z := a.Axis.X, a.Axis.Y, a.Axis.Z
+
+	return Matrix{
+		t*x*x + c, t*x*y - s*z, t*x*z + s*y,
+		t*x*y + s*z, t*y*y + c, t*y*z - s*x,
+		t*x*z - s*y, t*y*z + s*x, t*z*z + c,
+	}
+}
+
+// RotationMatrixToAxisAngle converts a rotation matrix to an axis-angle
+// rotation.
+//
+// The returned angle is in the range [0, pi]. When the angle is 0, the axis is
+// undefined, and the x-axis is returned.
+func RotationMatrixToAxisAngle(r Matrix) AxisAngle {
+	q := RotationMatrixToQuaternion(r)
+	n := quaternionVectorNorm(q)
+
+	if n == 0 {
+		return AxisAngle{Axis: Vector{1, 0, 0}}
+	}
+
+	return AxisAngle{
+		Axis:  Vector{q.X / n, q.Y / n, q.Z / n},
+		Angle: 2 * math.Atan2(n, q.W),
+	}
+}
+
+// RotationVectorToRotationMatrix converts a rotation vector to a rotation
+// matrix.
+//
+// The rotation vector is parallel to the axis of rotation, and its norm is the
+// angle of rotation in radians.
+func RotationVectorToRotationMatrix(v Vector) Matrix {
+	return QuaternionToRotationMatrix(rotationVectorToQuaternion(v))
+}
+
+// RotationMatrixToRotationVector converts a rotation matrix to a rotation
+// vector, as described by RotationVectorToRotationMatrix.
+//
+// The norm of the returned vector is in the range [0, pi].
+func RotationMatrixToRotationVector(r Matrix) Vector {
+	return quaternionToRotationVector(RotationMatrixToQuaternion(r))
+}
+
+// RotationAngle returns the angle in radians of the rotation between two
+// rotation matrices, which is the geodesic distance between them. The angle is
+// in the range [0, pi].
+func RotationAngle(a, b Matrix) float64 {
+	q := RotationMatrixToQuaternion(a.Transpose().Multiply(b))
+
+	return 2 * math.Atan2(quaternionVectorNorm(q), q.W)
+}
+
+// quaternionVectorNorm returns the Euclidean norm of the vector part of q,
+// without underflow for very small rotations.
+func quaternionVectorNorm(q Quaternion) float64 {
+	return math.Hypot(math.Hypot(q.X, q.Y), q.Z)
+}
+
+// rotationVectorToQuaternion converts a rotation vector to a unit quaternion.
+func rotationVectorToQuaternion(v Vector) Quaternion {
+	theta := v.Norm()
+
+	// sin(theta/2)/theta, with a series expansion near 0 to avoid dividing by 0
+	var k float64
+	if theta/2 < rotationVectorThreshold {
+		k = 0.5 - theta*theta/48
+	} else {
+		k = math.Sin(theta/2) / theta
+	}
+
+	return Quaternion{math.Cos(theta / 2), v.X * k, v.Y * k, v.Z * k}
+}
+
+// quaternionToRotationVector converts a unit quaternion to a rotation vector.
+// The norm of the returned vector is in the range [0, pi] when q.W is
+// non-negative.
+func quaternionToRotationVector(q Quaternion) Vector {
+	n := quaternionVectorNorm(q)
+
+	// theta/sin(theta/2), with an approximation near 0 to avoid dividing by 0
+	var k float64
+	if n < rotationVectorThreshold {
+		k = 2 / q.W
+	} else {
+		k = 2 * math.Atan2(n, q.W) / n
+	}
+
+	return Vector{q.X * k, q.Y * k, q.Z * k}
+}
@@ -0,0 +1,228 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_AxisAngleToRotationMatrix(t *testing.T) {
+	t.Run("it rotates about the axis", func(t *testing.T) {
+		r := AxisAngleToRotationMatrix(AxisAngle{Axis: Vector{Z: 1}, Angle: math.Pi / 2})
+
+		got := Vector{X: 1}.Transform(r)
+
+		if eq, ineq := equality.EqualToVector(got, Vector{Y: 1}, 1e-15); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it matches EulerXYZToRotationMatrix for single-axis rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := rapidgen.Radians().Draw(t, "angle")
+
+			tests := map[string]struct {
+				axis Vector
+				want Matrix
+			}{
+				"x": {Vector{X: 1}, EulerXYZToRotationMatrix(EulerXYZ{X: a})},
+				"y": {Vector{Y: 1}, EulerXYZToRotationMatrix(EulerXYZ{Y: a})},
+				"z": {Vector{Z: 1}, EulerXYZToRotationMatrix(EulerXYZ{Z: a})},
+			}
+
+			for name, tt := range tests {
+				got := AxisAngleToRotationMatrix(AxisAngle{Axis: tt.axis, Angle: a})
+
+				if eq, ineq := equality.EqualToMatrix(got, tt.want, 1e-14); !eq {
+					t.Logf("axis %s", name)
+					equality.ReportInequalities(t, ineq)
+				}
+			}
+		})
+	})
+}
+
+func Test_RotationMatrixToAxisAngle(t *testing.T) {
+	t.Run("it is the inverse of AxisAngleToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			got := AxisAngleToRotationMatrix(RotationMatrixToAxisAngle(r))
+
+			if eq, ineq := equality.EqualToMatrix(got, r, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns angles in the range [0, pi]", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			got := RotationMatrixToAxisAngle(r)
+
+			if got.Angle < 0 || got.Angle > math.Pi {
+				t.Errorf("got angle %v; want [0, pi]", got.Angle)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Axis.Norm(), 1, 1e-14); !eq {
+				equality.ReportInequality(t, "Axis norm", ineq)
+			}
+		})
+	})
+
+	t.Run("it handles the identity", func(t *testing.T) {
+		got := RotationMatrixToAxisAngle(XAxisNorth)
+
+		if got.Angle != 0 {
+			t.Errorf("got angle %v; want 0", got.Angle)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Axis.Norm(), 1, 0); !eq {
+			equality.ReportInequality(t, "Axis norm", ineq)
+		}
+	})
+
+	t.Run("it handles angles near pi", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			want := AxisAngle{
+				Axis:  rapidgen.UnitVector().Draw(t, "axis"),
+				Angle: math.Pi - rapid.Float64Range(0, 1e-6).Draw(t, "offset"),
+			}
+
+			got := RotationMatrixToAxisAngle(AxisAngleToRotationMatrix(want))
+
+			if eq, ineq := equality.EqualToFloat64(got.Angle, want.Angle, 1e-14); !eq {
+				equality.ReportInequality(t, "Angle", ineq)
+			}
+			if got.Axis.Dot(want.Axis) < 0 {
+				// near pi, the opposite axis is an equivalent rotation
+				got.Axis = got.Axis.Scale(-1)
+			}
+			if eq, ineq := equality.EqualToVector(got.Axis, want.Axis, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_RotationVectorToRotationMatrix(t *testing.T) {
+	t.Run("it matches AxisAngleToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := AxisAngle{
+				Axis:  rapidgen.UnitVector().Draw(t, "axis"),
+				Angle: rapid.Float64Range(0, math.Pi).Draw(t, "angle"),
+			}
+
+			got := RotationVectorToRotationMatrix(a.Axis.Scale(a.Angle))
+
+			if eq, ineq := equality.EqualToMatrix(got, AxisAngleToRotationMatrix(a), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it handles small rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.VectorRange(0, 1e-7).Draw(t, "rotationVector")
+
+			got := RotationVectorToRotationMatrix(v)
+			want := Matrix{
+				XX: 1, XY: -v.Z, XZ: v.Y,
+				YX: v.Z, YY: 1, YZ: -v.X,
+				ZX: -v.Y, ZY: v.X, ZZ: 1,
+			}
+
+			if eq, ineq := equality.EqualToMatrix(got, want, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_RotationMatrixToRotationVector(t *testing.T) {
+	t.Run("it is the inverse of RotationVectorToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.VectorRange(0, math.Pi*0.999).Draw(t, "rotationVector")
+
+			got := RotationMatrixToRotationVector(RotationVectorToRotationMatrix(v))
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it is the inverse of RotationVectorToRotationMatrix for small rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.VectorRange(0, 1e-7).Draw(t, "rotationVector")
+
+			got := RotationMatrixToRotationVector(RotationVectorToRotationMatrix(v))
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it round-trips Euler angles", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := EulerZYX{
+				Z: rapidgen.Radians().Draw(t, "z"),
+				Y: rapid.Float64Range(-math.Pi/2*0.99, math.Pi/2*0.99).Draw(t, "y"),
+				X: rapidgen.Radians().Draw(t, "x"),
+			}
+
+			got := RotationMatrixToEulerZYX(
+				RotationVectorToRotationMatrix(
+					RotationMatrixToRotationVector(EulerZYXToRotationMatrix(a)),
+				),
+			)
+
+			if eq, ineq := equality.EqualToEulerAnglesZYX(got, a, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_RotationAngle(t *testing.T) {
+	t.Run("it returns the angle of the relative rotation", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := rapidgen.RotationMatrix().Draw(t, "a")
+			d := AxisAngle{
+				Axis:  rapidgen.UnitVector().Draw(t, "axis"),
+				Angle: rapid.Float64Range(0, math.Pi).Draw(t, "angle"),
+			}
+
+			got := RotationAngle(a, a.Multiply(AxisAngleToRotationMatrix(d)))
+
+			if eq, ineq := equality.EqualToFloat64(got, d.Angle, 1e-12); !eq {
+				equality.ReportInequality(t, "Angle", ineq)
+			}
+		})
+	})
+
+	t.Run("it is symmetric", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := rapidgen.RotationMatrix().Draw(t, "a")
+			b := rapidgen.RotationMatrix().Draw(t, "b")
+
+			if eq, ineq := equality.EqualToFloat64(RotationAngle(a, b), RotationAngle(b, a), 1e-14); !eq {
+				equality.ReportInequality(t, "Angle", ineq)
+			}
+		})
+	})
+
+	t.Run("it returns 0 for identical rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := rapidgen.RotationMatrix().Draw(t, "a")
+
+			if eq, ineq := equality.EqualToFloat64(RotationAngle(a, a), 0, 1e-14); !eq {
+				equality.ReportInequality(t, "Angle", ineq)
+			}
+		})
+	})
+}
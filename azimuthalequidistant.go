@@ -0,0 +1,195 @@
+package nvector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// AzimuthalEquidistant is an azimuthal equidistant projection centered on a
+// position.
+//
+// The distance and azimuth from the center to each position are preserved. On
+// a sphere (an ellipsoid with zero flattening), distances are measured along
+// great circles. On other ellipsoids, distances are measured along geodesics,
+// using Vincenty's formulae.
+//
+// The projected y-axis points north from the center. If the center is at a
+// pole, north is the direction of the x-axis of the N frame returned by
+// ToRotationMatrix.
+//
+// See: https://en.wikipedia.org/wiki/Azimuthal_equidistant_projection
+type AzimuthalEquidistant struct {
+	// Ellipsoid is the reference ellipsoid.
+	Ellipsoid Ellipsoid
+	// Center is the n-vector at the center of the projection.
+	Center Vector
+	// FalseEasting and FalseNorthing are the projected coordinates of the
+	// center, in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the n-vector is antipodal to the center, where the
+// azimuth is undefined. On ellipsoids with non-zero flattening, an error is
+// also returned if the geodesic to a nearly antipodal n-vector can't be
+// solved.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p AzimuthalEquidistant) Forward(
+	v Vector,
+	f Matrix,
+) (ProjectedCoordinates, error) {
+	var s, azimuth float64
+
+	if p.Ellipsoid.Flattening == 0 {
+		n := v.Transform(ToRotationMatrix(p.Center, f).Transpose())
+		sinC := math.Hypot(n.X, n.Y)
+		if sinC == 0 && n.Z > 0 {
+			return ProjectedCoordinates{}, errors.New(
+				"n-vector is antipodal to the center of the projection",
+			)
+		}
+
+		s = p.Ellipsoid.SemiMajorAxis * math.Atan2(sinC, -n.Z)
+		azimuth = math.Atan2(n.Y, n.X)
+	} else {
+		var err error
+		s, azimuth, _, err = vincentyInverse(
+			p.center(f),
+			ToGeodeticCoordinates(v, f),
+			p.Ellipsoid,
+		)
+		if err != nil {
+			return ProjectedCoordinates{}, fmt.Errorf("azimuthal equidistant: %w", err)
+		}
+		azimuth += p.northOffset(f)
+	}
+
+	sinAz, cosAz := math.Sincos(azimuth)
+
+	return ProjectedCoordinates{
+		p.FalseEasting + s*sinAz,
+		p.FalseNorthing + s*cosAz,
+	}, nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p AzimuthalEquidistant) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	x := c.Easting - p.FalseEasting
+	y := c.Northing - p.FalseNorthing
+	s := math.Hypot(x, y)
+	azimuth := math.Atan2(x, y)
+
+	if p.Ellipsoid.Flattening == 0 {
+		sinC, cosC := math.Sincos(s / p.Ellipsoid.SemiMajorAxis)
+		sinAz, cosAz := math.Sincos(azimuth)
+
+		return Vector{sinC * cosAz, sinC * sinAz, -cosC}.
+			Transform(ToRotationMatrix(p.Center, f)), nil
+	}
+
+	return FromGeodeticCoordinates(
+		vincentyDirect(p.center(f), azimuth-p.northOffset(f), s, p.Ellipsoid),
+		f,
+	), nil
+}
+
+// Distortion returns the scale factors and meridian convergence of the
+// projection at an n-vector.
+//
+// On ellipsoids with non-zero flattening, the scale factor perpendicular to the
+// geodesic from the center is computed numerically, and is accurate to about 8
+// significant figures.
+//
+// An error is returned under the same conditions as Forward.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p AzimuthalEquidistant) Distortion(v Vector, f Matrix) (Distortion, error) {
+	if p.Ellipsoid.Flattening == 0 {
+		n := v.Transform(ToRotationMatrix(p.Center, f).Transpose())
+		sinC := math.Hypot(n.X, n.Y)
+		if sinC == 0 {
+			if n.Z > 0 {
+				return Distortion{}, errors.New(
+					"n-vector is antipodal to the center of the projection",
+				)
+			}
+
+			return Distortion{1, 1, 0}, nil
+		}
+
+		return azimuthalDistortion(
+			1,
+			math.Atan2(sinC, -n.Z)/sinC,
+			math.Atan2(n.Y, n.X),
+			awayFrom(p.Center, v, f),
+		), nil
+	}
+
+	center := p.center(f)
+	s, azimuth1, azimuth2, err := vincentyInverse(
+		center,
+		ToGeodeticCoordinates(v, f),
+		p.Ellipsoid,
+	)
+	if err != nil {
+		return Distortion{}, fmt.Errorf("azimuthal equidistant: %w", err)
+	}
+	if s == 0 {
+		return Distortion{1, 1, 0}, nil
+	}
+
+	// The reduced length of the geodesic is estimated from the distance between
+	// the ends of two geodesics with slightly different initial azimuths.
+	const delta = 1e-4
+	a := ToECEF(Position{Vector: FromGeodeticCoordinates(
+		vincentyDirect(center, azimuth1-delta, s, p.Ellipsoid),
+		f,
+	)}, p.Ellipsoid, f)
+	b := ToECEF(Position{Vector: FromGeodeticCoordinates(
+		vincentyDirect(center, azimuth1+delta, s, p.Ellipsoid),
+		f,
+	)}, p.Ellipsoid, f)
+	m := b.Sub(a).Norm() / (2 * math.Sin(delta))
+
+	return azimuthalDistortion(
+		1,
+		s/m,
+		azimuth1+p.northOffset(f),
+		azimuth2,
+	), nil
+}
+
+// center returns the geodetic coordinates of the center.
+func (p AzimuthalEquidistant) center(f Matrix) GeodeticCoordinates {
+	return ToGeodeticCoordinates(p.Center, f)
+}
+
+// northOffset returns the angle in radians between the meridian of the center
+// and the projected y-axis. This is only non-zero when the center is at a pole,
+// where the meridian is determined by the center's geodetic longitude, but the
+// projected y-axis is determined by ToRotationMatrix.
+func (p AzimuthalEquidistant) northOffset(f Matrix) float64 {
+	n := p.Center.Transform(f)
+	if n.Y != 0 || n.Z != 0 {
+		return 0
+	}
+
+	// At the north pole, the N frame's x-axis points along the meridian at
+	// 180°, but azimuths are measured from the meridian at the center's
+	// longitude, in the opposite direction. At the south pole, the x-axis points
+	// along the meridian at 0°.
+	lon := ToGeodeticCoordinates(p.Center, f).Longitude
+	if n.X > 0 {
+		return -lon
+	}
+
+	return lon
+}
@@ -0,0 +1,241 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_AzimuthalEquidistant_Forward(t *testing.T) {
+	t.Run("it preserves distance and azimuth on a sphere", func(t *testing.T) {
+		p := AzimuthalEquidistant{
+			Ellipsoid: Sphere(6371e3),
+			Center:    FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+
+		tests := map[string]struct {
+			lat, lon float64
+			want     ProjectedCoordinates
+		}{
+			"north": {10, 0, ProjectedCoordinates{0, 6371e3 * Radians(10)}},
+			"east":  {0, 10, ProjectedCoordinates{6371e3 * Radians(10), 0}},
+			"south": {-100, 0, ProjectedCoordinates{0, -6371e3 * Radians(100)}},
+			"west":  {0, -170, ProjectedCoordinates{-6371e3 * Radians(170), 0}},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				v := FromGeodeticCoordinates(
+					GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+					ZAxisNorth,
+				)
+
+				got, err := p.Forward(v, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if eq, ineq := equality.EqualToFloat64(got.Easting, tt.want.Easting, 1e-6); !eq {
+					equality.ReportInequality(t, "Easting", ineq)
+				}
+				if eq, ineq := equality.EqualToFloat64(got.Northing, tt.want.Northing, 1e-6); !eq {
+					equality.ReportInequality(t, "Northing", ineq)
+				}
+			})
+		}
+	})
+
+	t.Run("it preserves geodesic distance and azimuth on an ellipsoid", func(t *testing.T) {
+		// Flinders Peak to Buninyong, from Vincenty (1975)
+		p := AzimuthalEquidistant{
+			Ellipsoid: WGS84,
+			Center: FromGeodeticCoordinates(
+				GeodeticCoordinates{
+					Radians(-(37 + 57.0/60 + 3.72030/3600)),
+					Radians(144 + 25.0/60 + 29.52440/3600),
+				},
+				ZAxisNorth,
+			),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{
+				Radians(-(37 + 39.0/60 + 10.15610/3600)),
+				Radians(143 + 55.0/60 + 35.38390/3600),
+			},
+			ZAxisNorth,
+		)
+
+		got, err := p.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := math.Hypot(got.Easting, got.Northing)
+		azimuth := math.Atan2(got.Easting, got.Northing)
+
+		if eq, ineq := equality.EqualToFloat64(s, 54972.271, 1e-3); !eq {
+			equality.ReportInequality(t, "distance", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(
+			azimuth,
+			Radians(306+52.0/60+5.37/3600),
+			Radians(0.01/3600),
+		); !eq {
+			equality.ReportInequality(t, "azimuth", ineq)
+		}
+	})
+
+	t.Run("it orients the projection consistently at the poles", func(t *testing.T) {
+		for _, lat := range []float64{90, -90} {
+			center := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(lat), 0},
+				ZAxisNorth,
+			)
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(lat * 0.8), Radians(30)},
+				ZAxisNorth,
+			)
+
+			sphere, err := AzimuthalEquidistant{Ellipsoid: Sphere(6371e3), Center: center}.
+				Forward(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ellipsoid, err := AzimuthalEquidistant{Ellipsoid: WGS84, Center: center}.
+				Forward(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToRadians(
+				math.Atan2(ellipsoid.Easting, ellipsoid.Northing),
+				math.Atan2(sphere.Easting, sphere.Northing),
+				1e-12,
+			); !eq {
+				equality.ReportInequality(t, "azimuth", ineq)
+			}
+		}
+	})
+
+	t.Run("it returns an error at the antipode of the center", func(t *testing.T) {
+		p := AzimuthalEquidistant{
+			Ellipsoid: Sphere(6371e3),
+			Center:    Vector{X: 1, Y: 0, Z: 0},
+		}
+		v := Vector{X: -1, Y: 0, Z: 0}
+
+		if _, err := p.Forward(v, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+
+		p.Ellipsoid = WGS84
+		if _, err := p.Forward(v, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_AzimuthalEquidistant_Inverse(t *testing.T) {
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapid.SampledFrom([]Ellipsoid{Sphere(6371e3), WGS84, GRS80}).
+				Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			p := AzimuthalEquidistant{
+				Ellipsoid:     e,
+				Center:        rapidgen.UnitVector().Draw(t, "center"),
+				FalseEasting:  rapid.Float64Range(-1e6, 1e6).Draw(t, "falseEasting"),
+				FalseNorthing: rapid.Float64Range(-1e6, 1e6).Draw(t, "falseNorthing"),
+			}
+			v := rapidgen.UnitVector().Draw(t, "v")
+			if v.Dot(p.Center) < -0.5 {
+				// skip nearly antipodal n-vectors, which Vincenty's formulae may not
+				// solve
+				t.Skip()
+			}
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-11); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_AzimuthalEquidistant_Distortion(t *testing.T) {
+	t.Run("it preserves scale along great circles from the center", func(t *testing.T) {
+		p := AzimuthalEquidistant{
+			Ellipsoid: Sphere(6371e3),
+			Center:    FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(90)},
+			ZAxisNorth,
+		)
+
+		got, err := p.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, math.Pi/2, 1e-15); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 1, 1e-15); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 1e-15); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it has no distortion at the center", func(t *testing.T) {
+		for _, e := range []Ellipsoid{Sphere(6371e3), WGS84} {
+			p := AzimuthalEquidistant{
+				Ellipsoid: e,
+				Center: FromGeodeticCoordinates(
+					GeodeticCoordinates{Radians(-35), Radians(150)},
+					ZAxisNorth,
+				),
+			}
+
+			got, err := p.Distortion(p.Center, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != (Distortion{1, 1, 0}) {
+				t.Errorf("got %v, want no distortion", got)
+			}
+		}
+	})
+
+	t.Run("it returns an error at the antipode of the center", func(t *testing.T) {
+		p := AzimuthalEquidistant{
+			Ellipsoid: Sphere(6371e3),
+			Center:    Vector{X: 1, Y: 0, Z: 0},
+		}
+		v := Vector{X: -1, Y: 0, Z: 0}
+
+		if _, err := p.Distortion(v, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+
+		p.Ellipsoid = WGS84
+		if _, err := p.Distortion(v, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package nvector
+
+// Datum is a geodetic datum, defined by its reference ellipsoid and the
+// Helmert transformation to WGS 84.
+type Datum struct {
+	// Name is the name of the datum.
+	Name string
+	// Ellipsoid is the reference ellipsoid of the datum.
+	Ellipsoid Ellipsoid
+	// ToWGS84 is the transformation of ECEF position vectors from the datum to
+	// WGS 84.
+	ToWGS84 Helmert
+}
+
+var (
+	// DatumED50 is the European Datum 1950, with the transformation to WGS 84
+	// for the UK and Irish offshore areas.
+	//
+	// See: https://epsg.io/1311
+	DatumED50 = Datum{
+		"ED50",
+		International1924,
+		helmertFromPublished(-89.5, -93.8, -123.1, 0, 0, -0.156, 1.2),
+	}
+
+	// DatumETRS89 is the European Terrestrial Reference System 1989, which is
+	// treated as equal to WGS 84 at the meter level.
+	//
+	// See: https://epsg.io/1149
+	DatumETRS89 = Datum{"ETRS89", GRS80, Helmert{}}
+
+	// DatumIrl1975 is the Irish datum TM75 (Ireland 1965 as adjusted in 1975).
+	//
+	// See: https://epsg.io/1954
+	DatumIrl1975 = Datum{
+		"Irl1975",
+		AiryModified,
+		helmertFromPublished(482.5, -130.6, 564.6, -1.042, -0.214, -0.631, 8.15),
+	}
+
+	// DatumNAD27 is the North American Datum 1927, with the transformation to
+	// WGS 84 for the conterminous United States.
+	//
+	// See: https://epsg.io/1173
+	DatumNAD27 = Datum{
+		"NAD27",
+		Clarke1866,
+		helmertFromPublished(-8, 160, 176, 0, 0, 0, 0),
+	}
+
+	// DatumNAD83 is the North American Datum 1983, which is treated as equal to
+	// WGS 84 at the meter level.
+	//
+	// See: https://epsg.io/1188
+	DatumNAD83 = Datum{"NAD83", GRS80, Helmert{}}
+
+	// DatumNTF is the French Nouvelle Triangulation Française.
+	//
+	// See: https://epsg.io/1193
+	DatumNTF = Datum{
+		"NTF",
+		Clarke1880IGN,
+		helmertFromPublished(-168, -60, 320, 0, 0, 0, 0),
+	}
+
+	// DatumOSGB36 is the Ordnance Survey of Great Britain 1936 datum.
+	//
+	// See: https://epsg.io/1314
+	DatumOSGB36 = Datum{
+		"OSGB36",
+		Airy1830,
+		helmertFromPublished(446.448, -125.157, 542.06, 0.15, 0.247, 0.842, -20.489),
+	}
+
+	// DatumPotsdam is the German Deutsches Hauptdreiecksnetz (DHDN), also known
+	// as the Potsdam datum.
+	//
+	// See: https://epsg.io/1777
+	DatumPotsdam = Datum{
+		"Potsdam",
+		Bessel1841,
+		helmertFromPublished(598.1, 73.7, 418.2, 0.202, 0.045, -2.455, 6.7),
+	}
+
+	// DatumTokyo is the Japanese Tokyo datum.
+	DatumTokyo = Datum{
+		"Tokyo",
+		Bessel1841,
+		helmertFromPublished(-148, 507, 685, 0, 0, 0, 0),
+	}
+
+	// DatumWGS72 is the World Geodetic System 1972, with the transformation to
+	// WGS 84 from the worked example in EPSG Guidance Note 7-2.
+	//
+	// See: https://epsg.org/guidance-notes.html
+	DatumWGS72 = Datum{
+		"WGS72",
+		WGS72,
+		helmertFromPublished(0, 0, 4.5, 0, 0, 0.554, 0.219),
+	}
+
+	// DatumWGS84 is the World Geodetic System 1984.
+	DatumWGS84 = Datum{"WGS84", WGS84, Helmert{}}
+)
+
+// ConvertDatum returns the position p in the datum from, converted to a
+// position in the datum to, by transforming its ECEF position vector through
+// WGS 84. The coordinate frame f specifies the axes in which the n-vectors are
+// decomposed.
+func ConvertDatum(p Position, from, to Datum, f Matrix) Position {
+	v := from.ToWGS84.Transform(ToECEF(p, from.Ellipsoid, f), f)
+
+	return FromECEF(to.ToWGS84.InverseTransform(v, f), to.Ellipsoid, f)
+}
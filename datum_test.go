@@ -0,0 +1,87 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ConvertDatum(t *testing.T) {
+	t.Run("it converts Greenwich between WGS84 and OSGB36", func(t *testing.T) {
+		// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/test/latlon-ellipsoidal-datum-tests.js
+		wgs84 := GeodeticCoordinates{Latitude: Radians(51.4778), Longitude: Radians(-0.0016)}
+		osgb36 := GeodeticCoordinates{Latitude: Radians(51.4773), Longitude: Radians(0)}
+		p := Position{Vector: FromGeodeticCoordinates(wgs84, ZAxisNorth)}
+
+		got := ToGeodeticCoordinates(
+			ConvertDatum(p, DatumWGS84, DatumOSGB36, ZAxisNorth).Vector,
+			ZAxisNorth,
+		)
+
+		// the expected coordinates are rounded to 4 decimal places
+		if eq, ineq := equality.EqualToRadians(got.Latitude, osgb36.Latitude, Radians(5e-5)); !eq {
+			equality.ReportInequality(t, "Latitude", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Longitude, osgb36.Longitude, Radians(5e-5)); !eq {
+			equality.ReportInequality(t, "Longitude", ineq)
+		}
+	})
+
+	datums := map[string]Datum{
+		"ED50":    DatumED50,
+		"ETRS89":  DatumETRS89,
+		"Irl1975": DatumIrl1975,
+		"NAD27":   DatumNAD27,
+		"NAD83":   DatumNAD83,
+		"NTF":     DatumNTF,
+		"OSGB36":  DatumOSGB36,
+		"Potsdam": DatumPotsdam,
+		"Tokyo":   DatumTokyo,
+		"WGS72":   DatumWGS72,
+		"WGS84":   DatumWGS84,
+	}
+
+	for name, d := range datums {
+		t.Run(name, func(t *testing.T) {
+			t.Run("it round trips through WGS84", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					p := Position{
+						Vector: rapidgen.UnitVector().Draw(t, "vector"),
+						Depth:  rapid.Float64Range(-1e4, 1e5).Draw(t, "depth"),
+					}
+					f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+					got := ConvertDatum(ConvertDatum(p, d, DatumWGS84, f), DatumWGS84, d, f)
+
+					if eq, ineq := equality.EqualToVector(got.Vector, p.Vector, 1e-13); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+					if eq, ineq := equality.EqualToFloat64(got.Depth, p.Depth, 1e-6); !eq {
+						equality.ReportInequality(t, "Depth", ineq)
+					}
+				})
+			})
+
+			t.Run("it leaves positions unchanged within the same datum", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					p := Position{
+						Vector: rapidgen.UnitVector().Draw(t, "vector"),
+						Depth:  rapid.Float64Range(-1e4, 1e5).Draw(t, "depth"),
+					}
+
+					got := ConvertDatum(p, d, d, ZAxisNorth)
+
+					if eq, ineq := equality.EqualToVector(got.Vector, p.Vector, 1e-13); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+					if eq, ineq := equality.EqualToFloat64(got.Depth, p.Depth, 1e-6); !eq {
+						equality.ReportInequality(t, "Depth", ineq)
+					}
+				})
+			})
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package nvector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AngleFormat is a notation for angles in degrees.
+type AngleFormat int
+
+const (
+	// DecimalDegrees formats angles as decimal degrees, e.g. "45.2058°".
+	DecimalDegrees AngleFormat = iota
+	// DegreesMinutes formats angles as degrees and decimal minutes, e.g.
+	// "45°12.345′".
+	DegreesMinutes
+	// DegreesMinutesSeconds formats angles as degrees, minutes, and decimal
+	// seconds, e.g. "45°12′20.7″".
+	DegreesMinutesSeconds
+)
+
+// ParseAngle parses an angle in degrees from a string, and returns it in
+// radians.
+//
+// The angle may be given in decimal degrees, degrees and minutes, or degrees,
+// minutes, and seconds, separated by whitespace or the symbols °, ′, and ″
+// (or ', ", and :), e.g. "45.2058", "45 12.345", or "45°12′20.7″". Only the
+// last component may have a fractional part, and minutes and seconds must be
+// less than 60.
+//
+// Negative angles may be given with a leading minus sign, or with one of the
+// hemisphere letters S or W before or after the angle, e.g. "S 45 12.345" or
+// "45°12′20.7″W". The letters N and E denote positive angles. An e between
+// digits is an exponent rather than a hemisphere letter, e.g. "4.52058e1".
+func ParseAngle(s string) (float64, error) {
+	deg, _, err := parseDegrees(tokenizeAngles(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid angle %q: %w", s, err)
+	}
+
+	return Radians(deg), nil
+}
+
+// FormatAngle formats an angle in radians as a string in degrees, using the
+// given format, with the last component rounded to the given number of
+// decimal places, e.g. "-45°12′20.7″". Negative angles have a leading minus
+// sign.
+//
+// Rounding carries into the larger components, so minutes and seconds are
+// always less than 60.
+func FormatAngle(r float64, format AngleFormat, decimals int) string {
+	s, zero := formatDegrees(Degrees(r), format, decimals)
+	if r < 0 && !zero {
+		return "-" + s
+	}
+
+	return s
+}
+
+// ParseGeodeticCoordinates parses geodetic coordinates from a string.
+//
+// The string must contain a latitude and longitude in any of the notations
+// accepted by ParseAngle, e.g. "59°57′N 10°43′E", "N 45 12.345 W 075 30.2",
+// or "59.95, -10.7167". The angles may be separated by a comma or semicolon.
+// Otherwise, they are separated by the hemisphere letters, or into two halves
+// with the same number of components.
+//
+// If hemisphere letters are given, the longitude may come first. Otherwise,
+// the latitude must come first.
+func ParseGeodeticCoordinates(s string) (GeodeticCoordinates, error) {
+	var lat, lon float64
+	var latH, lonH string
+
+	a, b, err := splitAngles(s)
+	if err == nil {
+		lat, latH, err = parseDegrees(a)
+	}
+	if err == nil {
+		lon, lonH, err = parseDegrees(b)
+	}
+	if err != nil {
+		return GeodeticCoordinates{}, fmt.Errorf(
+			"invalid geodetic coordinates %q: %w",
+			s,
+			err,
+		)
+	}
+
+	isLat := func(h string) bool { return h == "N" || h == "S" }
+	isLon := func(h string) bool { return h == "E" || h == "W" }
+
+	if isLon(latH) || isLat(lonH) {
+		lat, lon = lon, lat
+		latH, lonH = lonH, latH
+	}
+	if isLon(latH) || isLat(lonH) {
+		return GeodeticCoordinates{}, fmt.Errorf(
+			"invalid geodetic coordinates %q: hemispheres %s and %s",
+			s,
+			latH,
+			lonH,
+		)
+	}
+
+	if math.Abs(lat) > 90 {
+		return GeodeticCoordinates{}, fmt.Errorf("invalid latitude %v", lat)
+	}
+	if math.Abs(lon) > 180 {
+		return GeodeticCoordinates{}, fmt.Errorf("invalid longitude %v", lon)
+	}
+
+	return GeodeticCoordinatesFromDegrees(lat, lon), nil
+}
+
+// Format formats geodetic coordinates as a string, using the given format for
+// the latitude and longitude, with the last component of each rounded to the
+// given number of decimal places, and followed by a hemisphere letter, e.g.
+// "59°57′00″N 10°43′00″E".
+//
+// Rounding carries into the larger components, so minutes and seconds are
+// always less than 60.
+func (c GeodeticCoordinates) Format(format AngleFormat, decimals int) string {
+	lat, latZero := formatDegrees(Degrees(c.Latitude), format, decimals)
+	lon, lonZero := formatDegrees(Degrees(c.Longitude), format, decimals)
+
+	latH, lonH := "N", "E"
+	if c.Latitude < 0 && !latZero {
+		latH = "S"
+	}
+	if c.Longitude < 0 && !lonZero {
+		lonH = "W"
+	}
+
+	return lat + latH + " " + lon + lonH
+}
+
+// formatDegrees formats the magnitude of an angle in degrees, and returns
+// whether it rounds to zero.
+func formatDegrees(deg float64, format AngleFormat, decimals int) (string, bool) {
+	decimals = max(decimals, 0)
+	p := math.Pow10(decimals)
+	a := math.Abs(deg)
+
+	// the width of zero-padded minutes and seconds
+	width := 2
+	if decimals > 0 {
+		width += decimals + 1
+	}
+
+	// round to an integer number of the smallest unit, so that rounding
+	// carries into the larger components
+	switch format {
+	case DegreesMinutes:
+		units := math.Round(a * 60 * p)
+		d := math.Floor(units / (60 * p))
+		m := (units - d*60*p) / p
+
+		return fmt.Sprintf("%.0f°%0*.*f′", d, width, decimals, m), units == 0
+	case DegreesMinutesSeconds:
+		units := math.Round(a * 3600 * p)
+		d := math.Floor(units / (3600 * p))
+		rem := units - d*3600*p
+		m := math.Floor(rem / (60 * p))
+		s := (rem - m*60*p) / p
+
+		return fmt.Sprintf("%.0f°%02.0f′%0*.*f″", d, m, width, decimals, s),
+			units == 0
+	}
+
+	units := math.Round(a * p)
+
+	return fmt.Sprintf("%.*f°", decimals, units/p), units == 0
+}
+
+// tokenizeAngles splits a string into numbers and hemisphere letters.
+func tokenizeAngles(s string) []string {
+	rs := []rune(s)
+
+	var b strings.Builder
+	for i, r := range rs {
+		if (r == 'e' || r == 'E') && isExponent(rs, i) {
+			b.WriteRune(r)
+			continue
+		}
+
+		switch r {
+		case 'N', 'S', 'E', 'W', 'n', 's', 'e', 'w':
+			b.WriteRune(' ')
+			b.WriteRune(unicode.ToUpper(r))
+			b.WriteRune(' ')
+		case '°', 'º', '˚', '\'', '′', '’', '"', '″', '”', ':':
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.Fields(b.String())
+}
+
+// isExponent returns true if the e at index i of rs separates a number from its
+// exponent, i.e. it's preceded by a digit or decimal point, and followed by a
+// digit or a signed digit.
+func isExponent(rs []rune, i int) bool {
+	if i == 0 || !(unicode.IsDigit(rs[i-1]) || rs[i-1] == '.') {
+		return false
+	}
+
+	j := i + 1
+	if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+		j++
+	}
+
+	return j < len(rs) && unicode.IsDigit(rs[j])
+}
+
+// splitAngles splits a string containing two angles into the tokens of each
+// angle.
+func splitAngles(s string) ([]string, []string, error) {
+	if i := strings.IndexAny(s, ",;"); i >= 0 {
+		if strings.ContainsAny(s[i+1:], ",;") {
+			return nil, nil, errors.New("more than two angles")
+		}
+
+		return tokenizeAngles(s[:i]), tokenizeAngles(s[i+1:]), nil
+	}
+
+	tokens := tokenizeAngles(s)
+
+	var letters []int
+	for i, t := range tokens {
+		if isHemisphere(t) {
+			letters = append(letters, i)
+		}
+	}
+
+	switch {
+	case len(letters) == 2 && letters[0] == 0:
+		return tokens[:letters[1]], tokens[letters[1]:], nil
+	case len(letters) == 2:
+		return tokens[:letters[0]+1], tokens[letters[0]+1:], nil
+	case len(letters) == 0 && len(tokens)%2 == 0:
+		return tokens[:len(tokens)/2], tokens[len(tokens)/2:], nil
+	}
+
+	return nil, nil, errors.New("expected two angles")
+}
+
+// parseDegrees parses the tokens of an angle in degrees, and returns the
+// signed angle and its hemisphere letter, if any.
+func parseDegrees(tokens []string) (float64, string, error) {
+	var h string
+	if len(tokens) > 0 && isHemisphere(tokens[0]) {
+		h, tokens = tokens[0], tokens[1:]
+	}
+	if len(tokens) > 0 && isHemisphere(tokens[len(tokens)-1]) {
+		if h != "" {
+			return 0, "", errors.New("more than one hemisphere")
+		}
+		h, tokens = tokens[len(tokens)-1], tokens[:len(tokens)-1]
+	}
+	if len(tokens) < 1 || len(tokens) > 3 {
+		return 0, "", fmt.Errorf("expected 1 to 3 components, got %d", len(tokens))
+	}
+
+	var negative bool
+	deg := 0.0
+	scale := 1.0
+	for i, t := range tokens {
+		if i == 0 && (t[0] == '-' || t[0] == '+') {
+			if h != "" {
+				return 0, "", errors.New("both a sign and a hemisphere")
+			}
+			negative = t[0] == '-'
+			t = t[1:]
+		}
+		if !isUnsignedNumber(t) {
+			return 0, "", fmt.Errorf("invalid component %q", tokens[i])
+		}
+		if i < len(tokens)-1 && strings.Contains(t, ".") {
+			return 0, "", fmt.Errorf("fractional component %q before the last", t)
+		}
+
+		n, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, "", fmt.Errorf("invalid component %q", tokens[i])
+		}
+		if i > 0 && n >= 60 {
+			return 0, "", fmt.Errorf("component %q is not less than 60", t)
+		}
+
+		deg += n / scale
+		scale *= 60
+	}
+
+	if negative || h == "S" || h == "W" {
+		deg = -deg
+	}
+
+	return deg, h, nil
+}
+
+// isUnsignedNumber returns true if the token is an unsigned decimal number,
+// with an optional exponent, e.g. "45.5" or "4.55e1".
+func isUnsignedNumber(t string) bool {
+	mantissa, exponent, ok := strings.Cut(strings.ToLower(t), "e")
+	if ok {
+		if exponent != "" && (exponent[0] == '+' || exponent[0] == '-') {
+			exponent = exponent[1:]
+		}
+		if exponent == "" || strings.Trim(exponent, "0123456789") != "" {
+			return false
+		}
+	}
+
+	return mantissa != "" && strings.Trim(mantissa, "0123456789.") == ""
+}
+
+// isHemisphere returns true if the token is a hemisphere letter.
+func isHemisphere(t string) bool {
+	return t == "N" || t == "S" || t == "E" || t == "W"
+}
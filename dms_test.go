@@ -0,0 +1,376 @@
+package nvector_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ParseAngle(t *testing.T) {
+	t.Run("it parses angles", func(t *testing.T) {
+		tests := map[string]struct {
+			s    string
+			want float64
+		}{
+			"decimal degrees":               {"45.2058", 45.2058},
+			"decimal degrees with a symbol": {"45.2058°", 45.2058},
+			"degrees and minutes":           {"45 12.345", 45 + 12.345/60},
+			"degrees and minutes with symbols": {
+				"45°12.345'",
+				45 + 12.345/60,
+			},
+			"degrees, minutes, and seconds": {"45 12 20.7", 45 + 12.0/60 + 20.7/3600},
+			"degrees, minutes, and seconds with symbols": {
+				"45°12′20.7″",
+				45 + 12.0/60 + 20.7/3600,
+			},
+			"degrees, minutes, and seconds with ASCII symbols": {
+				`45°12'20.7"`,
+				45 + 12.0/60 + 20.7/3600,
+			},
+			"colons":                {"45:12:20.7", 45 + 12.0/60 + 20.7/3600},
+			"negative":              {"-45 30", -45.5},
+			"negative zero degrees": {"-0 30", -0.5},
+			"positive":              {"+45 30", 45.5},
+			"leading hemisphere":    {"S 45 30", -45.5},
+			"trailing hemisphere":   {"45°30′W", -45.5},
+			"north":                 {"45°30′N", 45.5},
+			"east":                  {"E45.5", 45.5},
+			"lower case hemisphere": {"45.5 w", -45.5},
+			"leading zeros":         {"075 05 03", 75 + 5.0/60 + 3.0/3600},
+			"surrounding space":     {"  45.5  ", 45.5},
+			"exponent":              {"1e3", 1000},
+			"signed exponent":       {"-4.55E+1", -45.5},
+			"negative exponent":     {"45 3e-1", 45 + 0.3/60},
+			"exponent and east":     {"4.55e1E", 45.5},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				got, err := ParseAngle(tt.s)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if eq, ineq := equality.EqualToRadians(got, Radians(tt.want), 1e-15); !eq {
+					equality.ReportInequality(t, "Angle", ineq)
+				}
+			})
+		}
+	})
+
+	t.Run("it returns an error for invalid angles", func(t *testing.T) {
+		tests := map[string]string{
+			"empty":                     "",
+			"only a hemisphere":         "N",
+			"too many components":       "45 12 20 1",
+			"fractional degrees":        "45.5 30",
+			"fractional minutes":        "45 30.5 20",
+			"60 minutes":                "45 60",
+			"60 seconds":                "45 30 60",
+			"negative minutes":          "45 -30",
+			"sign and hemisphere":       "-45 30 S",
+			"two hemispheres":           "N 45 30 S",
+			"invalid characters":        "45x",
+			"invalid number":            "45.5.5",
+			"infinity":                  "Inf",
+			"not a number":              "NaN",
+			"hexadecimal":               "0x2D",
+			"sign without a number":     "-",
+			"hemisphere in the middle":  "45 N 30",
+			"separators without digits": "°′″",
+			"exponent without digits":   "45 1e+",
+			"exponent without mantissa": "N e3",
+		}
+
+		for name, s := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := ParseAngle(s); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_FormatAngle(t *testing.T) {
+	t.Run("it formats angles", func(t *testing.T) {
+		tests := map[string]struct {
+			r        float64
+			format   AngleFormat
+			decimals int
+			want     string
+		}{
+			"decimal degrees": {
+				Radians(45.20583), DecimalDegrees, 4, "45.2058°",
+			},
+			"degrees and minutes": {
+				Radians(45 + 12.345/60), DegreesMinutes, 3, "45°12.345′",
+			},
+			"degrees, minutes, and seconds": {
+				Radians(45 + 12.0/60 + 20.7/3600), DegreesMinutesSeconds, 1, "45°12′20.7″",
+			},
+			"zero padding": {
+				Radians(1 + 2.0/60 + 3.0/3600), DegreesMinutesSeconds, 2, "1°02′03.00″",
+			},
+			"zero padding without decimals": {
+				Radians(1 + 2.0/60), DegreesMinutes, 0, "1°02′",
+			},
+			"negative": {
+				Radians(-45.5), DegreesMinutes, 0, "-45°30′",
+			},
+			"negative rounding to zero": {
+				Radians(-1e-9), DegreesMinutesSeconds, 1, "0°00′00.0″",
+			},
+			"rounding seconds into minutes": {
+				Radians(10 + 59.0/60 + 59.96/3600), DegreesMinutesSeconds, 1, "11°00′00.0″",
+			},
+			"rounding minutes into degrees": {
+				Radians(10 + 59.9996/60), DegreesMinutes, 3, "11°00.000′",
+			},
+			"rounding seconds without decimals": {
+				Radians(89 + 59.0/60 + 59.5/3600), DegreesMinutesSeconds, 0, "90°00′00″",
+			},
+			"negative decimals": {
+				Radians(45.6), DecimalDegrees, -1, "46°",
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				if got := FormatAngle(tt.r, tt.format, tt.decimals); got != tt.want {
+					t.Errorf("got %q; want %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("it never produces 60 minutes or seconds", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := Radians(rapid.Float64Range(-180, 180).Draw(t, "angle"))
+			format := rapid.SampledFrom([]AngleFormat{
+				DegreesMinutes,
+				DegreesMinutesSeconds,
+			}).Draw(t, "format")
+			decimals := rapid.IntRange(0, 6).Draw(t, "decimals")
+
+			s := FormatAngle(r, format, decimals)
+
+			if strings.Contains(s, "°60") || strings.Contains(s, "′60") {
+				t.Errorf("got %q; want minutes and seconds less than 60", s)
+			}
+		})
+	})
+
+	t.Run("it round trips with ParseAngle", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := Radians(rapid.Float64Range(-180, 180).Draw(t, "angle"))
+			format := rapid.SampledFrom([]AngleFormat{
+				DecimalDegrees,
+				DegreesMinutes,
+				DegreesMinutesSeconds,
+			}).Draw(t, "format")
+			decimals := rapid.IntRange(0, 6).Draw(t, "decimals")
+
+			got, err := ParseAngle(FormatAngle(r, format, decimals))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the error is at most half of the last decimal place, plus rounding
+			units := map[AngleFormat]float64{
+				DecimalDegrees:        1,
+				DegreesMinutes:        60,
+				DegreesMinutesSeconds: 3600,
+			}
+			tol := Radians(0.5/(units[format]*math.Pow10(decimals))) + 1e-15
+
+			if eq, ineq := equality.EqualToRadians(got, r, tol); !eq {
+				equality.ReportInequality(t, "Angle", ineq)
+			}
+		})
+	})
+}
+
+func Test_ParseGeodeticCoordinates(t *testing.T) {
+	t.Run("it parses coordinates", func(t *testing.T) {
+		tests := map[string]struct {
+			s                string
+			wantLat, wantLon float64
+		}{
+			"degrees and minutes with trailing hemispheres": {
+				"59°57'N 10°43'E",
+				59 + 57.0/60,
+				10 + 43.0/60,
+			},
+			"degrees and minutes with leading hemispheres": {
+				"N 45 12.345 W 075 30.2",
+				45 + 12.345/60,
+				-(75 + 30.2/60),
+			},
+			"degrees, minutes, and seconds": {
+				"33°51′35.9″S 151°12′40″E",
+				-(33 + 51.0/60 + 35.9/3600),
+				151 + 12.0/60 + 40.0/3600,
+			},
+			"decimal degrees with a comma": {
+				"59.95, -10.7167",
+				59.95,
+				-10.7167,
+			},
+			"decimal degrees with a semicolon": {
+				"-33.86;151.21",
+				-33.86,
+				151.21,
+			},
+			"decimal degrees with whitespace": {
+				"-33.86 151.21",
+				-33.86,
+				151.21,
+			},
+			"degrees and minutes with whitespace": {
+				"-33 51.6 151 12.6",
+				-(33 + 51.6/60),
+				151 + 12.6/60,
+			},
+			"longitude first": {
+				"10°43'E 59°57'N",
+				59 + 57.0/60,
+				10 + 43.0/60,
+			},
+			"longitude first with a comma": {
+				"W 75.5, N 45.5",
+				45.5,
+				-75.5,
+			},
+			"one hemisphere": {
+				"45.5, 75.5 W",
+				45.5,
+				-75.5,
+			},
+			"limits": {
+				"90 S 180 W",
+				-90,
+				-180,
+			},
+			"exponents": {
+				"4.55e1, -1e1",
+				45.5,
+				-10,
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				got, err := ParseGeodeticCoordinates(tt.s)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if eq, ineq := equality.EqualToRadians(got.Latitude, Radians(tt.wantLat), 1e-15); !eq {
+					equality.ReportInequality(t, "Latitude", ineq)
+				}
+				if eq, ineq := equality.EqualToRadians(got.Longitude, Radians(tt.wantLon), 1e-15); !eq {
+					equality.ReportInequality(t, "Longitude", ineq)
+				}
+			})
+		}
+	})
+
+	t.Run("it returns an error for invalid coordinates", func(t *testing.T) {
+		tests := map[string]string{
+			"empty":                    "",
+			"one angle":                "45.5",
+			"three angles":             "45.5, 10.5, 20.5",
+			"odd number of components": "45 30 10",
+			"two latitudes":            "45 N 10 S",
+			"two longitudes":           "45 E 10 W",
+			"latitude out of range":    "91, 10",
+			"longitude out of range":   "45, 181",
+			"invalid angle":            "45 60, 10",
+			"missing angle":            "45.5,",
+			"three hemispheres":        "N 45 E 10 W",
+		}
+
+		for name, s := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := ParseGeodeticCoordinates(s); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_GeodeticCoordinates_Format(t *testing.T) {
+	t.Run("it formats coordinates", func(t *testing.T) {
+		tests := map[string]struct {
+			c        GeodeticCoordinates
+			format   AngleFormat
+			decimals int
+			want     string
+		}{
+			"decimal degrees": {
+				GeodeticCoordinatesFromDegrees(59.95, 10.716667),
+				DecimalDegrees,
+				4,
+				"59.9500°N 10.7167°E",
+			},
+			"degrees and minutes": {
+				GeodeticCoordinatesFromDegrees(45+12.345/60, -(75 + 30.2/60)),
+				DegreesMinutes,
+				3,
+				"45°12.345′N 75°30.200′W",
+			},
+			"degrees, minutes, and seconds": {
+				GeodeticCoordinatesFromDegrees(-(33 + 51.0/60 + 35.9/3600), 151+12.0/60+40.0/3600),
+				DegreesMinutesSeconds,
+				1,
+				"33°51′35.9″S 151°12′40.0″E",
+			},
+			"zero": {
+				GeodeticCoordinatesFromDegrees(-1e-9, -1e-9),
+				DegreesMinutesSeconds,
+				0,
+				"0°00′00″N 0°00′00″E",
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				if got := tt.c.Format(tt.format, tt.decimals); got != tt.want {
+					t.Errorf("got %q; want %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("it round trips with ParseGeodeticCoordinates", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			d := rapidgen.GeodeticCoordinates().Draw(t, "coords")
+			c := GeodeticCoordinatesFromDegrees(d.Latitude, d.Longitude)
+			format := rapid.SampledFrom([]AngleFormat{
+				DecimalDegrees,
+				DegreesMinutes,
+				DegreesMinutesSeconds,
+			}).Draw(t, "format")
+
+			got, err := ParseGeodeticCoordinates(c.Format(format, 9))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToRadians(got.Latitude, c.Latitude, 1e-10); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(got.Longitude, c.Longitude, 1e-10); !eq {
+				equality.ReportInequality(t, "Longitude", ineq)
+			}
+		})
+	})
+}
@@ -14,7 +14,7 @@ func FromECEF(v Vector, e Ellipsoid, f Matrix) Position {
 	v = v.Transform(f)
 
 	// e2 = eccentricity^2
-	e2 := 2*e.Flattening - math.Pow(e.Flattening, 2)
+	e2 := e.EccentricitySquared()
 
 	// The following code implements equation (23) from Gade (2010):
 	R2 := math.Pow(v.Y, 2) + math.Pow(v.Z, 2)
@@ -67,7 +67,7 @@ func ToECEF(v Position, e Ellipsoid, f Matrix) Vector {
 	nv := v.Vector.Transform(f)
 
 	// semi-minor axis:
-	eb := e.SemiMinorAxis
+	eb := e.semiMinorAxis()
 
 	// The following code implements equation (22) in Gade (2010):
 
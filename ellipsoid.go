@@ -1,18 +1,104 @@
 package nvector
 
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
 // Ellipsoid is a reference ellipsoid.
+//
+// The shape of the ellipsoid is defined by SemiMajorAxis and Flattening, which
+// are used by all calculations. SemiMinorAxis is expected to equal
+// SemiMajorAxis * (1 - Flattening), but is otherwise ignored. Use NewEllipsoid
+// or Validate to detect inconsistent values.
 type Ellipsoid struct {
+	// SemiMajorAxis is the equatorial radius in meters.
 	SemiMajorAxis float64
+	// SemiMinorAxis is the polar radius in meters.
 	SemiMinorAxis float64
-	Flattening    float64
+	// Flattening is (a - b) / a, where a and b are the semi-major and semi-minor
+	// axes.
+	Flattening float64
 }
 
 var (
+	// Airy1830 is the Airy 1830 ellipsoid.
+	//
+	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L40
+	Airy1830 = ellipsoidFromInverseFlattening(6377563.396, 299.3249646)
+
+	// AiryModified is the modified Airy ellipsoid.
+	//
+	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L41
+	AiryModified = ellipsoidFromInverseFlattening(6377340.189, 299.3249646)
+
+	// AustralianNational is the Australian National Spheroid.
+	//
+	// See: https://epsg.io/7003-ellipsoid
+	AustralianNational = ellipsoidFromInverseFlattening(6378160, 298.25)
+
+	// Bessel1841 is the Bessel 1841 ellipsoid.
+	//
+	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L42
+	Bessel1841 = ellipsoidFromInverseFlattening(6377397.155, 299.1528128)
+
+	// CGCS2000 is the China Geodetic Coordinate System 2000 ellipsoid.
+	//
+	// See: https://epsg.io/1024-ellipsoid
+	CGCS2000 = ellipsoidFromInverseFlattening(6378137, 298.257222101)
+
+	// Clarke1866 is the Clarke 1866 ellipsoid.
+	//
+	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L43
+	Clarke1866 = ellipsoidFromInverseFlattening(6378206.4, 294.978698214)
+
+	// Clarke1880 is the Clarke 1880 (RGS) ellipsoid.
+	//
+	// See: https://epsg.io/7012-ellipsoid
+	Clarke1880 = ellipsoidFromInverseFlattening(6378249.145, 293.465)
+
+	// Clarke1880IGN is the Clarke 1880 (IGN) ellipsoid.
+	//
+	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L44
+	Clarke1880IGN = ellipsoidFromInverseFlattening(6378249.2, 293.466021294)
+
+	// Everest1830 is the Everest 1830 (1937 Adjustment) ellipsoid.
+	//
+	// See: https://epsg.io/7015-ellipsoid
+	Everest1830 = ellipsoidFromInverseFlattening(6377276.345, 300.8017)
+
+	// GRS67 is the Geodetic Reference System 1967 ellipsoid.
+	//
+	// See: https://epsg.io/7036-ellipsoid
+	GRS67 = ellipsoidFromInverseFlattening(6378160, 298.247167427)
+
 	// GRS80 is the Geodetic Reference System 1980 ellipsoid.
 	//
 	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L45
 	GRS80 = Ellipsoid{6378137, 6356752.314140356, 1 / 298.257222101}
 
+	// Helmert1906 is the Helmert 1906 ellipsoid.
+	//
+	// See: https://epsg.io/7020-ellipsoid
+	Helmert1906 = ellipsoidFromInverseFlattening(6378200, 298.3)
+
+	// International1924 is the International 1924 (Hayford 1909) ellipsoid.
+	//
+	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L46
+	International1924 = ellipsoidFromInverseFlattening(6378388, 297)
+
+	// Krassovsky1940 is the Krassovsky 1940 ellipsoid.
+	//
+	// See: https://epsg.io/7024-ellipsoid
+	Krassovsky1940 = ellipsoidFromInverseFlattening(6378245, 298.3)
+
+	// PZ90 is the Parametry Zemli 1990 (PZ-90) ellipsoid.
+	//
+	// See: https://epsg.io/7054-ellipsoid
+	PZ90 = ellipsoidFromInverseFlattening(6378136, 298.257839303)
+
 	// WGS72 is the World Geodetic System 1972 ellipsoid.
 	//
 	// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-datum.js#L47
@@ -28,3 +114,224 @@ var (
 func Sphere(radius float64) Ellipsoid {
 	return Ellipsoid{radius, radius, 0}
 }
+
+// NewEllipsoid returns an ellipsoid with the semi-major axis a, the
+// semi-minor axis b, and the flattening f. An error is returned if the
+// ellipsoid is invalid, as described by Validate.
+func NewEllipsoid(a, b, f float64) (Ellipsoid, error) {
+	e := Ellipsoid{a, b, f}
+	if err := e.Validate(); err != nil {
+		return Ellipsoid{}, err
+	}
+
+	return e, nil
+}
+
+// ellipsoidAxisTolerance is the maximum difference between the semi-minor axis
+// and the semi-minor axis derived from the flattening, relative to the
+// semi-major axis.
+const ellipsoidAxisTolerance = 1e-12
+
+// Validate returns an error if the ellipsoid is invalid. An ellipsoid is valid
+// if its axes are positive and finite, it is oblate or a sphere (the
+// semi-minor axis is not greater than the semi-major axis), and its flattening
+// matches its axes.
+func (e Ellipsoid) Validate() error {
+	a, b, f := e.SemiMajorAxis, e.SemiMinorAxis, e.Flattening
+
+	if !(a > 0) || math.IsInf(a, 0) {
+		return fmt.Errorf("invalid ellipsoid: semi-major axis %v is not positive and finite", a)
+	}
+	if !(b > 0) || math.IsInf(b, 0) {
+		return fmt.Errorf("invalid ellipsoid: semi-minor axis %v is not positive and finite", b)
+	}
+	if b > a {
+		return fmt.Errorf(
+			"invalid ellipsoid: semi-minor axis %v is greater than semi-major axis %v (prolate)",
+			b,
+			a,
+		)
+	}
+	if !(f >= 0 && f < 1) {
+		return fmt.Errorf("invalid ellipsoid: flattening %v is not in the range [0, 1)", f)
+	}
+	if d := math.Abs(a*(1-f) - b); !(d <= ellipsoidAxisTolerance*a) {
+		return fmt.Errorf(
+			"invalid ellipsoid: flattening %v does not match the axes (want %v)",
+			f,
+			(a-b)/a,
+		)
+	}
+
+	return nil
+}
+
+// Eccentricity returns the first eccentricity of the ellipsoid.
+func (e Ellipsoid) Eccentricity() float64 {
+	return math.Sqrt(e.EccentricitySquared())
+}
+
+// EccentricitySquared returns the square of the first eccentricity of the
+// ellipsoid.
+func (e Ellipsoid) EccentricitySquared() float64 {
+	return e.Flattening * (2 - e.Flattening)
+}
+
+// MeridionalRadius returns the radius of curvature of the meridian at the
+// position of the n-vector v, in meters. f is the coordinate frame in which
+// the n-vector is decomposed.
+func (e Ellipsoid) MeridionalRadius(v Vector, f Matrix) float64 {
+	e2 := e.EccentricitySquared()
+	w2 := 1 - e2*math.Pow(v.Transform(f).X, 2)
+
+	return e.SemiMajorAxis * (1 - e2) / (w2 * math.Sqrt(w2))
+}
+
+// PrimeVerticalRadius returns the radius of curvature in the prime vertical,
+// perpendicular to the meridian, at the position of the n-vector v, in meters.
+// f is the coordinate frame in which the n-vector is decomposed.
+func (e Ellipsoid) PrimeVerticalRadius(v Vector, f Matrix) float64 {
+	e2 := e.EccentricitySquared()
+
+	return e.SemiMajorAxis / math.Sqrt(1-e2*math.Pow(v.Transform(f).X, 2))
+}
+
+// LocalSphereRadius returns the radius of the sphere that best fits the
+// ellipsoid at the position of the n-vector v, in meters. This is the Gaussian
+// mean radius, the geometric mean of the meridional and prime vertical radii
+// of curvature. f is the coordinate frame in which the n-vector is decomposed.
+//
+// Use Sphere(e.LocalSphereRadius(v, f)) for spherical calculations in a region
+// around v.
+func (e Ellipsoid) LocalSphereRadius(v Vector, f Matrix) float64 {
+	return math.Sqrt(e.MeridionalRadius(v, f) * e.PrimeVerticalRadius(v, f))
+}
+
+// MeanRadius returns the arithmetic mean radius of the ellipsoid, (2a + b) / 3,
+// in meters.
+func (e Ellipsoid) MeanRadius() float64 {
+	return (2*e.SemiMajorAxis + e.semiMinorAxis()) / 3
+}
+
+// AuthalicRadius returns the radius of the sphere with the same surface area
+// as the ellipsoid, in meters.
+//
+// See: EPSG Guidance Note 7-2, Section 3.10.2
+func (e Ellipsoid) AuthalicRadius() float64 {
+	if e.Flattening == 0 {
+		return e.SemiMajorAxis
+	}
+
+	ecc := e.Eccentricity()
+	qP := 1 + (1-ecc*ecc)*math.Atanh(ecc)/ecc
+
+	return e.SemiMajorAxis * math.Sqrt(qP/2)
+}
+
+// VolumetricRadius returns the radius of the sphere with the same volume as the
+// ellipsoid, in meters.
+func (e Ellipsoid) VolumetricRadius() float64 {
+	return math.Cbrt(e.SemiMajorAxis * e.SemiMajorAxis * e.semiMinorAxis())
+}
+
+// semiMinorAxis returns the semi-minor axis derived from the semi-major axis
+// and the flattening, which takes precedence over SemiMinorAxis.
+func (e Ellipsoid) semiMinorAxis() float64 {
+	return e.SemiMajorAxis * (1 - e.Flattening)
+}
+
+// EllipsoidFromInverseFlattening returns an ellipsoid with the semi-major axis
+// a and the inverse flattening rf, from which the semi-minor axis is derived.
+// An infinite rf gives a sphere. An error is returned if the ellipsoid is
+// invalid, as described by Validate, e.g. if rf is not greater than 1.
+func EllipsoidFromInverseFlattening(a, rf float64) (Ellipsoid, error) {
+	e := ellipsoidFromInverseFlattening(a, rf)
+	if err := e.Validate(); err != nil {
+		return Ellipsoid{}, err
+	}
+
+	return e, nil
+}
+
+// ellipsoidFromInverseFlattening returns an ellipsoid with the semi-major axis
+// a and the inverse flattening rf, without validation.
+func ellipsoidFromInverseFlattening(a, rf float64) Ellipsoid {
+	return Ellipsoid{a, a * (1 - 1/rf), 1 / rf}
+}
+
+// NamedEllipsoid is an ellipsoid in the catalogue returned by Ellipsoids.
+type NamedEllipsoid struct {
+	// Name is the name of the ellipsoid, e.g. "Airy 1830".
+	Name string
+	// EPSG is the EPSG code of the ellipsoid, e.g. 7001.
+	EPSG int
+	// Ellipsoid is the ellipsoid.
+	Ellipsoid Ellipsoid
+}
+
+// ellipsoids is the catalogue of named ellipsoids.
+var ellipsoids = []NamedEllipsoid{
+	{"Airy 1830", 7001, Airy1830},
+	{"Airy Modified", 7002, AiryModified},
+	{"Australian National", 7003, AustralianNational},
+	{"Bessel 1841", 7004, Bessel1841},
+	{"CGCS2000", 1024, CGCS2000},
+	{"Clarke 1866", 7008, Clarke1866},
+	{"Clarke 1880", 7012, Clarke1880},
+	{"Clarke 1880 (IGN)", 7011, Clarke1880IGN},
+	{"Everest 1830", 7015, Everest1830},
+	{"GRS67", 7036, GRS67},
+	{"GRS80", 7019, GRS80},
+	{"Helmert 1906", 7020, Helmert1906},
+	{"International 1924", 7022, International1924},
+	{"Krassovsky 1940", 7024, Krassovsky1940},
+	{"PZ-90", 7054, PZ90},
+	{"WGS72", 7043, WGS72},
+	{"WGS84", 7030, WGS84},
+}
+
+// Ellipsoids returns the catalogue of named ellipsoids, which includes every
+// ellipsoid defined by this package.
+func Ellipsoids() []NamedEllipsoid {
+	return append([]NamedEllipsoid(nil), ellipsoids...)
+}
+
+// EllipsoidFromName returns the ellipsoid in the catalogue with the given
+// name. Case, spaces, and punctuation are ignored, so "Clarke 1880 (IGN)" can
+// also be found as "clarke1880ign" or "Clarke1880IGN". An error is returned if
+// there is no such ellipsoid.
+func EllipsoidFromName(name string) (Ellipsoid, error) {
+	key := ellipsoidNameKey(name)
+	for _, e := range ellipsoids {
+		if ellipsoidNameKey(e.Name) == key {
+			return e.Ellipsoid, nil
+		}
+	}
+
+	return Ellipsoid{}, fmt.Errorf("unknown ellipsoid %q", name)
+}
+
+// EllipsoidFromEPSG returns the ellipsoid in the catalogue with the given EPSG
+// ellipsoid code, e.g. 7030 for WGS84. An error is returned if there is no
+// such ellipsoid.
+func EllipsoidFromEPSG(code int) (Ellipsoid, error) {
+	for _, e := range ellipsoids {
+		if e.EPSG == code {
+			return e.Ellipsoid, nil
+		}
+	}
+
+	return Ellipsoid{}, fmt.Errorf("unknown EPSG ellipsoid code %d", code)
+}
+
+// ellipsoidNameKey returns the name of an ellipsoid in lower case, without any
+// characters other than letters and digits.
+func ellipsoidNameKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, name)
+}
@@ -1,10 +1,13 @@
 package nvector_test
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/ezzatron/nvector-go"
 	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
 )
 
 func Test_GRS80(t *testing.T) {
@@ -74,3 +77,399 @@ func Test_Sphere(t *testing.T) {
 		equality.ReportInequality(t, "Flattening", ineq)
 	}
 }
+
+func Test_Ellipsoids(t *testing.T) {
+	tests := map[string]struct {
+		e     Ellipsoid
+		wantA float64
+		wantF float64
+	}{
+		"Airy1830":           {Airy1830, 6377563.396, 1 / 299.3249646},
+		"AiryModified":       {AiryModified, 6377340.189, 1 / 299.3249646},
+		"AustralianNational": {AustralianNational, 6378160, 1 / 298.25},
+		"Bessel1841":         {Bessel1841, 6377397.155, 1 / 299.1528128},
+		"CGCS2000":           {CGCS2000, 6378137, 1 / 298.257222101},
+		"Clarke1866":         {Clarke1866, 6378206.4, 1 / 294.978698214},
+		"Clarke1880":         {Clarke1880, 6378249.145, 1 / 293.465},
+		"Clarke1880IGN":      {Clarke1880IGN, 6378249.2, 1 / 293.466021294},
+		"Everest1830":        {Everest1830, 6377276.345, 1 / 300.8017},
+		"GRS67":              {GRS67, 6378160, 1 / 298.247167427},
+		"Helmert1906":        {Helmert1906, 6378200, 1 / 298.3},
+		"International1924":  {International1924, 6378388, 1 / 297.0},
+		"Krassovsky1940":     {Krassovsky1940, 6378245, 1 / 298.3},
+		"PZ90":               {PZ90, 6378136, 1 / 298.257839303},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			wantB := tt.wantA * (1 - tt.wantF)
+
+			if eq, ineq := equality.EqualToFloat64(tt.e.SemiMajorAxis, tt.wantA, 0); !eq {
+				equality.ReportInequality(t, "SemiMajorAxis", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(tt.e.SemiMinorAxis, wantB, 1e-8); !eq {
+				equality.ReportInequality(t, "SemiMinorAxis", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(tt.e.Flattening, tt.wantF, 1e-18); !eq {
+				equality.ReportInequality(t, "Flattening", ineq)
+			}
+		})
+	}
+
+	t.Run("it includes every ellipsoid in the catalogue", func(t *testing.T) {
+		got := map[Ellipsoid]bool{}
+		for _, e := range Ellipsoids() {
+			got[e.Ellipsoid] = true
+		}
+
+		for name, tt := range tests {
+			if !got[tt.e] {
+				t.Errorf("%s is not in the catalogue", name)
+			}
+		}
+		for name, e := range map[string]Ellipsoid{"GRS80": GRS80, "WGS72": WGS72, "WGS84": WGS84} {
+			if !got[e] {
+				t.Errorf("%s is not in the catalogue", name)
+			}
+		}
+	})
+
+	t.Run("it has unique names and EPSG codes", func(t *testing.T) {
+		names := map[string]bool{}
+		codes := map[int]bool{}
+
+		for _, e := range Ellipsoids() {
+			if names[e.Name] {
+				t.Errorf("duplicate name %q", e.Name)
+			}
+			if codes[e.EPSG] {
+				t.Errorf("duplicate EPSG code %d", e.EPSG)
+			}
+
+			names[e.Name] = true
+			codes[e.EPSG] = true
+		}
+	})
+}
+
+func Test_EllipsoidFromInverseFlattening(t *testing.T) {
+	t.Run("it derives the semi-minor axis and flattening", func(t *testing.T) {
+		got, err := EllipsoidFromInverseFlattening(6378137, 298.257223563)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.SemiMajorAxis, WGS84.SemiMajorAxis, 0); !eq {
+			equality.ReportInequality(t, "SemiMajorAxis", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.SemiMinorAxis, WGS84.SemiMinorAxis, 1e-8); !eq {
+			equality.ReportInequality(t, "SemiMinorAxis", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Flattening, WGS84.Flattening, 1e-18); !eq {
+			equality.ReportInequality(t, "Flattening", ineq)
+		}
+	})
+
+	t.Run("it returns a sphere for an infinite inverse flattening", func(t *testing.T) {
+		got, err := EllipsoidFromInverseFlattening(6371000, math.Inf(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := Sphere(6371000); got != want {
+			t.Errorf("got %+v; want %+v", got, want)
+		}
+	})
+
+	t.Run("it returns an error for invalid inverse flattenings", func(t *testing.T) {
+		tests := map[string]float64{
+			"zero":     0,
+			"negative": -298.257223563,
+			"one":      1,
+			"NaN":      math.NaN(),
+		}
+
+		for name, rf := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := EllipsoidFromInverseFlattening(6378137, rf); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_EllipsoidFromName(t *testing.T) {
+	tests := map[string]Ellipsoid{
+		"Airy 1830":         Airy1830,
+		"airy1830":          Airy1830,
+		"Clarke 1880 (IGN)": Clarke1880IGN,
+		"Clarke1880IGN":     Clarke1880IGN,
+		"clarke 1880":       Clarke1880,
+		"PZ-90":             PZ90,
+		"pz90":              PZ90,
+		"WGS 84":            WGS84,
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := EllipsoidFromName(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != want {
+				t.Errorf("got %+v; want %+v", got, want)
+			}
+		})
+	}
+
+	t.Run("it finds every ellipsoid in the catalogue", func(t *testing.T) {
+		for _, e := range Ellipsoids() {
+			got, err := EllipsoidFromName(e.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != e.Ellipsoid {
+				t.Errorf("%s: got %+v; want %+v", e.Name, got, e.Ellipsoid)
+			}
+		}
+	})
+
+	t.Run("it returns an error for unknown names", func(t *testing.T) {
+		for _, name := range []string{"", "Airy", "Clarke 1858"} {
+			if _, err := EllipsoidFromName(name); err == nil {
+				t.Errorf("%q: expected an error", name)
+			}
+		}
+	})
+}
+
+func Test_EllipsoidFromEPSG(t *testing.T) {
+	tests := map[int]Ellipsoid{
+		7001: Airy1830,
+		7004: Bessel1841,
+		7008: Clarke1866,
+		7019: GRS80,
+		7022: International1924,
+		7024: Krassovsky1940,
+		7030: WGS84,
+	}
+
+	for code, want := range tests {
+		got, err := EllipsoidFromEPSG(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Errorf("%d: got %+v; want %+v", code, got, want)
+		}
+	}
+
+	t.Run("it returns an error for unknown codes", func(t *testing.T) {
+		for _, code := range []int{0, 4326, 7000} {
+			if _, err := EllipsoidFromEPSG(code); err == nil {
+				t.Errorf("%d: expected an error", code)
+			}
+		}
+	})
+}
+
+func Test_Ellipsoid_DerivedQuantities(t *testing.T) {
+	// See: NIMA TR8350.2, Table 3.3
+	tests := map[string]struct {
+		got  func(Ellipsoid) float64
+		want float64
+		tol  float64
+	}{
+		"Eccentricity":        {Ellipsoid.Eccentricity, 8.1819190842622e-2, 1e-15},
+		"EccentricitySquared": {Ellipsoid.EccentricitySquared, 6.69437999014e-3, 1e-14},
+		"MeanRadius":          {Ellipsoid.MeanRadius, 6371008.7714, 1e-4},
+		"AuthalicRadius":      {Ellipsoid.AuthalicRadius, 6371007.1810, 1e-4},
+		"VolumetricRadius":    {Ellipsoid.VolumetricRadius, 6371000.7900, 1e-4},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if eq, ineq := equality.EqualToFloat64(tt.got(WGS84), tt.want, tt.tol); !eq {
+				equality.ReportInequality(t, name, ineq)
+			}
+		})
+	}
+
+	t.Run("it returns the radius for spheres", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapid.Float64Range(1, 1e7).Draw(t, "radius")
+			e := Sphere(r)
+
+			if eq, ineq := equality.EqualToFloat64(e.Eccentricity(), 0, 0); !eq {
+				equality.ReportInequality(t, "Eccentricity", ineq)
+			}
+			for name, got := range map[string]float64{
+				"MeanRadius":       e.MeanRadius(),
+				"AuthalicRadius":   e.AuthalicRadius(),
+				"VolumetricRadius": e.VolumetricRadius(),
+			} {
+				if eq, ineq := equality.EqualToFloat64(got, r, 1e-8); !eq {
+					equality.ReportInequality(t, name, ineq)
+				}
+			}
+		})
+	})
+}
+
+func Test_Ellipsoid_RadiiOfCurvature(t *testing.T) {
+	equator := FromGeodeticCoordinates(GeodeticCoordinates{}, ZAxisNorth)
+	pole := FromGeodeticCoordinates(GeodeticCoordinates{Latitude: Radians(90)}, ZAxisNorth)
+
+	// See: NIMA TR8350.2, Table 3.3 (polar radius of curvature)
+	polar := 6399593.6258
+	a, b := WGS84.SemiMajorAxis, WGS84.SemiMinorAxis
+
+	tests := map[string]struct {
+		got  float64
+		want float64
+	}{
+		"meridional at the equator":     {WGS84.MeridionalRadius(equator, ZAxisNorth), b * b / a},
+		"prime vertical at the equator": {WGS84.PrimeVerticalRadius(equator, ZAxisNorth), 6378137},
+		"meridional at the pole":        {WGS84.MeridionalRadius(pole, ZAxisNorth), polar},
+		"prime vertical at the pole":    {WGS84.PrimeVerticalRadius(pole, ZAxisNorth), polar},
+		"local sphere at the pole":      {WGS84.LocalSphereRadius(pole, ZAxisNorth), polar},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if eq, ineq := equality.EqualToFloat64(tt.got, tt.want, 1e-4); !eq {
+				equality.ReportInequality(t, "Radius", ineq)
+			}
+		})
+	}
+
+	t.Run("it orders the radii of curvature", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			v := rapidgen.UnitVector().Draw(t, "vector")
+			f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+			m := e.MeridionalRadius(v, f)
+			n := e.PrimeVerticalRadius(v, f)
+			r := e.LocalSphereRadius(v, f)
+
+			if !(e.SemiMajorAxis*(1-e.EccentricitySquared()) <= m*(1+1e-15) && m <= n*(1+1e-15)) {
+				t.Errorf("got meridional radius %v and prime vertical radius %v", m, n)
+			}
+			if !(m <= r*(1+1e-15) && r <= n*(1+1e-15)) {
+				t.Errorf("got local sphere radius %v; want between %v and %v", r, m, n)
+			}
+		})
+	})
+
+	t.Run("it is independent of the coordinate frame", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			c := rapidgen.GeodeticCoordinates().Draw(t, "coordinates")
+			f := rapidgen.RotationMatrix().Draw(t, "frame")
+			c = GeodeticCoordinates{Latitude: Radians(c.Latitude), Longitude: Radians(c.Longitude)}
+
+			got := e.MeridionalRadius(FromGeodeticCoordinates(c, f), f)
+			want := e.MeridionalRadius(FromGeodeticCoordinates(c, ZAxisNorth), ZAxisNorth)
+
+			if eq, ineq := equality.EqualToFloat64(got, want, 1e-6); !eq {
+				equality.ReportInequality(t, "MeridionalRadius", ineq)
+			}
+		})
+	})
+}
+
+func Test_Ellipsoid_Validate(t *testing.T) {
+	t.Run("it accepts the catalogue ellipsoids", func(t *testing.T) {
+		for _, e := range Ellipsoids() {
+			if err := e.Ellipsoid.Validate(); err != nil {
+				t.Errorf("%s: %v", e.Name, err)
+			}
+		}
+	})
+
+	t.Run("it accepts spheres", func(t *testing.T) {
+		if err := Sphere(6371e3).Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("it rejects invalid ellipsoids", func(t *testing.T) {
+		a, b, f := WGS84.SemiMajorAxis, WGS84.SemiMinorAxis, WGS84.Flattening
+
+		tests := map[string]Ellipsoid{
+			"zero":                    {},
+			"negative semi-major":     {-a, b, f},
+			"negative semi-minor":     {a, -b, f},
+			"infinite semi-major":     {math.Inf(1), b, f},
+			"not a number":            {math.NaN(), b, f},
+			"prolate":                 {b, a, (b - a) / b},
+			"negative flattening":     {a, a, -f},
+			"flattening of 1":         {a, 0, 1},
+			"mistyped flattening":     {a, b, 1 / 298.357223563},
+			"flattening not inverted": {a, b, 298.257223563},
+			"mismatched semi-minor":   {a, b + 1, f},
+		}
+
+		for name, e := range tests {
+			t.Run(name, func(t *testing.T) {
+				if err := e.Validate(); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_NewEllipsoid(t *testing.T) {
+	t.Run("it returns valid ellipsoids", func(t *testing.T) {
+		got, err := NewEllipsoid(6378137, 6356752.314245179, 1/298.257223563)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != WGS84 {
+			t.Errorf("got %+v; want %+v", got, WGS84)
+		}
+	})
+
+	t.Run("it returns an error for invalid ellipsoids", func(t *testing.T) {
+		if _, err := NewEllipsoid(6378137, 6356752.314245179, 1/298.357223563); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_Ellipsoid_Precedence(t *testing.T) {
+	t.Run("it uses the flattening rather than the semi-minor axis", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			p := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "vector"),
+				Depth:  rapid.Float64Range(-1e4, 1e5).Draw(t, "depth"),
+			}
+			inconsistent := e
+			inconsistent.SemiMinorAxis += 1000
+
+			v := ToECEF(p, e, ZAxisNorth)
+
+			if eq, ineq := equality.EqualToVector(ToECEF(p, inconsistent, ZAxisNorth), v, 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+
+			got := FromECEF(v, inconsistent, ZAxisNorth)
+			want := FromECEF(v, e, ZAxisNorth)
+
+			if eq, ineq := equality.EqualToVector(got.Vector, want.Vector, 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Depth, want.Depth, 0); !eq {
+				equality.ReportInequality(t, "Depth", ineq)
+			}
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package nvector
+
+import (
+	"fmt"
+)
+
+// epsgProjStrings are the PROJ strings of the projected coordinate reference
+// systems supported by ProjectionFromEPSG, other than the UTM zones.
+var epsgProjStrings = map[int]string{
+	// RGF93 v1 / Lambert-93
+	2154: "+proj=lcc +lat_0=46.5 +lon_0=3 +lat_1=49 +lat_2=44 +x_0=700000 +y_0=6600000 +ellps=GRS80",
+	// NZGD2000 / New Zealand Transverse Mercator 2000
+	2193: "+proj=tmerc +lat_0=0 +lon_0=173 +k=0.9996 +x_0=1600000 +y_0=10000000 +ellps=GRS80",
+	// WGS 84 / Antarctic Polar Stereographic
+	3031: "+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84",
+	// ETRS89-extended / LCC Europe
+	3034: "+proj=lcc +lat_0=52 +lon_0=10 +lat_1=35 +lat_2=65 +x_0=4000000 +y_0=2800000 +ellps=GRS80",
+	// ETRS89-extended / LAEA Europe
+	3035: "+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +ellps=GRS80",
+	// GDA94 / Geoscience Australia Lambert
+	3112: "+proj=lcc +lat_0=0 +lon_0=134 +lat_1=-18 +lat_2=-36 +x_0=0 +y_0=0 +ellps=GRS80",
+	// WGS 84 / NSIDC Sea Ice Polar Stereographic North
+	3413: "+proj=stere +lat_0=90 +lat_ts=70 +lon_0=-45 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / North Pole LAEA Bering Sea
+	3571: "+proj=laea +lat_0=90 +lon_0=180 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / North Pole LAEA Alaska
+	3572: "+proj=laea +lat_0=90 +lon_0=-150 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / North Pole LAEA Canada
+	3573: "+proj=laea +lat_0=90 +lon_0=-100 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / North Pole LAEA Atlantic
+	3574: "+proj=laea +lat_0=90 +lon_0=-40 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / North Pole LAEA Europe
+	3575: "+proj=laea +lat_0=90 +lon_0=10 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / North Pole LAEA Russia
+	3576: "+proj=laea +lat_0=90 +lon_0=90 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / Pseudo-Mercator
+	3857: "+proj=webmerc +datum=WGS84",
+	// WGS 84 / UPS North (E,N)
+	5041: "+proj=ups +datum=WGS84",
+	// WGS 84 / UPS South (E,N)
+	5042: "+proj=ups +south +datum=WGS84",
+	// WGS 84 / NSIDC EASE-Grid 2.0 North
+	6931: "+proj=laea +lat_0=90 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84",
+	// WGS 84 / NSIDC EASE-Grid 2.0 South
+	6932: "+proj=laea +lat_0=-90 +lon_0=0 +x_0=0 +y_0=0 +datum=WGS84",
+	// OSGB36 / British National Grid
+	27700: "+proj=tmerc +lat_0=49 +lon_0=-2 +k=0.9996012717 +x_0=400000 +y_0=-100000 +datum=OSGB36",
+}
+
+// ProjectionFromEPSG returns the projection of a projected coordinate reference
+// system, identified by its EPSG code.
+//
+// The supported codes are the WGS 84 UTM zones (32601-32660 and 32701-32760),
+// the ETRS89 UTM zones (25828-25838), and 2154, 2193, 3031, 3034, 3035, 3112,
+// 3413, 3571-3576, 3857, 5041, 5042, 6931, 6932, and 27700. An error is
+// returned for any other code.
+//
+// Only the projection and its ellipsoid are described. Datum transformations
+// and axis orders are not applied.
+//
+// The centers of the azimuthal projections are decomposed in f.
+//
+// See: https://epsg.io/
+func ProjectionFromEPSG(code int, f Matrix) (Projection, error) {
+	s, ok := epsgProjString(code)
+	if !ok {
+		return nil, fmt.Errorf("unsupported EPSG code %d", code)
+	}
+
+	return ParseProjString(s, f)
+}
+
+// epsgProjString returns the PROJ string of a projected coordinate reference
+// system, identified by its EPSG code.
+func epsgProjString(code int) (string, bool) {
+	switch {
+	case code >= 32601 && code <= 32660:
+		return fmt.Sprintf("+proj=utm +zone=%d +datum=WGS84", code-32600), true
+	case code >= 32701 && code <= 32760:
+		return fmt.Sprintf("+proj=utm +zone=%d +south +datum=WGS84", code-32700), true
+	case code >= 25828 && code <= 25838:
+		return fmt.Sprintf("+proj=utm +zone=%d +ellps=GRS80", code-25800), true
+	}
+
+	s, ok := epsgProjStrings[code]
+
+	return s, ok
+}
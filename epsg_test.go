@@ -0,0 +1,122 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+)
+
+func Test_ProjectionFromEPSG(t *testing.T) {
+	t.Run("it matches the UTM zones", func(t *testing.T) {
+		tests := map[string]struct {
+			code     int
+			lat, lon float64
+			e        Ellipsoid
+		}{
+			"WGS 84 / UTM zone 31N": {32631, 48.8582, 2.2945, WGS84},
+			"WGS 84 / UTM zone 56S": {32756, -33.857, 151.215, WGS84},
+			"ETRS89 / UTM zone 33N": {25833, 52.5163, 13.3777, GRS80},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				v := FromGeodeticCoordinates(
+					GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+					ZAxisNorth,
+				)
+				want, err := ToUTM(v, tt.e, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				p, err := ProjectionFromEPSG(tt.code, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := p.Forward(v, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if eq, ineq := equality.EqualToFloat64(got.Easting, want.Easting, 1e-9); !eq {
+					equality.ReportInequality(t, "Easting", ineq)
+				}
+				if eq, ineq := equality.EqualToFloat64(got.Northing, want.Northing, 1e-9); !eq {
+					equality.ReportInequality(t, "Northing", ineq)
+				}
+			})
+		}
+	})
+
+	t.Run("it matches the EPSG worked examples", func(t *testing.T) {
+		tests := map[string]struct {
+			code     int
+			lat, lon float64
+			want     ProjectedCoordinates
+		}{
+			"OSGB36 / British National Grid": {
+				27700, 50.5, 0.5, ProjectedCoordinates{577274.99, 69740.50},
+			},
+			"WGS 84 / Pseudo-Mercator": {
+				3857,
+				24 + 22.0/60 + 54.433/3600,
+				-100 - 20.0/60,
+				ProjectedCoordinates{-11169055.58, 2800000.00},
+			},
+			"ETRS89-extended / LAEA Europe": {
+				3035, 50, 5, ProjectedCoordinates{3962799.45, 2999718.85},
+			},
+			"WGS 84 / UPS North (E,N)": {
+				5041, 73, 44, ProjectedCoordinates{3320416.75, 632668.43},
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				p, err := ProjectionFromEPSG(tt.code, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := p.Forward(
+					FromGeodeticCoordinates(
+						GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+						ZAxisNorth,
+					),
+					ZAxisNorth,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if eq, ineq := equality.EqualToFloat64(got.Easting, tt.want.Easting, 1e-2); !eq {
+					equality.ReportInequality(t, "Easting", ineq)
+				}
+				if eq, ineq := equality.EqualToFloat64(got.Northing, tt.want.Northing, 1e-2); !eq {
+					equality.ReportInequality(t, "Northing", ineq)
+				}
+			})
+		}
+	})
+
+	t.Run("it supports each documented code", func(t *testing.T) {
+		for _, code := range []int{
+			2154, 2193, 3031, 3034, 3035, 3112, 3413, 3571, 3576, 3857, 5041,
+			5042, 6931, 6932, 27700,
+			25828, 25838, 32601, 32660, 32701, 32760,
+		} {
+			if _, err := ProjectionFromEPSG(code, ZAxisNorth); err != nil {
+				t.Errorf("EPSG:%d: %v", code, err)
+			}
+		}
+	})
+
+	t.Run("it returns an error for unsupported codes", func(t *testing.T) {
+		for _, code := range []int{0, 4326, 25827, 32600, 32661, 32700, 32761} {
+			if _, err := ProjectionFromEPSG(code, ZAxisNorth); err == nil {
+				t.Errorf("EPSG:%d: expected an error", code)
+			}
+		}
+	})
+}
@@ -0,0 +1,227 @@
+package nvector
+
+import (
+	"math"
+)
+
+// EulerSequence is an order of rotation axes for Euler angles.
+//
+// The six Tait-Bryan sequences rotate about three different axes. The six
+// proper Euler sequences rotate about the same axis first and last.
+type EulerSequence int
+
+const (
+	// EulerSequenceXYZ is the Tait-Bryan sequence X, Y, Z.
+	EulerSequenceXYZ EulerSequence = iota
+	// EulerSequenceXZY is the Tait-Bryan sequence X, Z, Y.
+	EulerSequenceXZY
+	// EulerSequenceYXZ is the Tait-Bryan sequence Y, X, Z.
+	EulerSequenceYXZ
+	// EulerSequenceYZX is the Tait-Bryan sequence Y, Z, X.
+	EulerSequenceYZX
+	// EulerSequenceZXY is the Tait-Bryan sequence Z, X, Y.
+	EulerSequenceZXY
+	// EulerSequenceZYX is the Tait-Bryan sequence Z, Y, X.
+	EulerSequenceZYX
+	// EulerSequenceXYX is the proper Euler sequence X, Y, X.
+	EulerSequenceXYX
+	// EulerSequenceXZX is the proper Euler sequence X, Z, X.
+	EulerSequenceXZX
+	// EulerSequenceYXY is the proper Euler sequence Y, X, Y.
+	EulerSequenceYXY
+	// EulerSequenceYZY is the proper Euler sequence Y, Z, Y.
+	EulerSequenceYZY
+	// EulerSequenceZXZ is the proper Euler sequence Z, X, Z.
+	EulerSequenceZXZ
+	// EulerSequenceZYZ is the proper Euler sequence Z, Y, Z.
+	EulerSequenceZYZ
+)
+
+// eulerSequenceAxes holds the axis indices of each Euler sequence, where 0, 1,
+// and 2 are the x, y, and z axes.
+var eulerSequenceAxes = [...][3]int{
+	EulerSequenceXYZ: {0, 1, 2},
+	EulerSequenceXZY: {0, 2, 1},
+	EulerSequenceYXZ: {1, 0, 2},
+	EulerSequenceYZX: {1, 2, 0},
+	EulerSequenceZXY: {2, 0, 1},
+	EulerSequenceZYX: {2, 1, 0},
+	EulerSequenceXYX: {0, 1, 0},
+	EulerSequenceXZX: {0, 2, 0},
+	EulerSequenceYXY: {1, 0, 1},
+	EulerSequenceYZY: {1, 2, 1},
+	EulerSequenceZXZ: {2, 0, 2},
+	EulerSequenceZYZ: {2, 1, 2},
+}
+
+// String returns the axes of the sequence, e.g. "XYZ".
+func (s EulerSequence) String() string {
+	axes := eulerSequenceAxes[s]
+
+	return string([]byte{'X' + byte(axes[0]), 'X' + byte(axes[1]), 'X' + byte(axes[2])})
+}
+
+// IsProper returns true if s is a proper Euler sequence, and false if it is a
+// Tait-Bryan sequence.
+func (s EulerSequence) IsProper() bool {
+	axes := eulerSequenceAxes[s]
+
+	return axes[0] == axes[2]
+}
+
+// EulerAngles is a set of Euler angles in any sequence.
+type EulerAngles struct {
+	// Sequence is the order of the rotation axes.
+	Sequence EulerSequence
+	// Extrinsic is true if the rotations are about the fixed axes of the
+	// original frame, and false if they are about the new axes produced by each
+	// rotation (intrinsic). EulerXYZ and EulerZYX are intrinsic.
+	Extrinsic bool
+	// First, Second, and Third are the angles of rotation in radians about the
+	// first, second, and third axes of the sequence.
+	First, Second, Third float64
+}
+
+// EulerAnglesToRotationMatrix converts Euler angles in any sequence to a
+// rotation matrix.
+func EulerAnglesToRotationMatrix(a EulerAngles) Matrix {
+	axes := eulerSequenceAxes[a.Sequence]
+	r1 := axisRotationMatrix(axes[0], a.First)
+	r2 := axisRotationMatrix(axes[1], a.Second)
+	r3 := axisRotationMatrix(axes[2], a.Third)
+
+	if a.Extrinsic {
+		// rotations about fixed axes compose by multiplying on the left
+		return r3.Multiply(r2).Multiply(r1)
+	}
+
+	// rotations about new axes compose by multiplying on the right
+	return r1.Multiply(r2).Multiply(r3)
+}
+
+// RotationMatrixToEulerAngles converts a rotation matrix to Euler angles in
+// the sequence s. If extrinsic is true, the angles are for rotations about
+// fixed axes, otherwise they are for rotations about new axes.
+//
+// The second angle is in the range [-pi/2, pi/2] for Tait-Bryan sequences, and
+// [0, pi] for proper Euler sequences. The other angles are in the range
+// [-pi, pi].
+//
+// Close to the singularity (gimbal lock), only the sum or difference of the
+// first and third angles is defined. In this case, the first angle of an
+// intrinsic sequence, or the third angle of an extrinsic sequence, is set to 0.
+func RotationMatrixToEulerAngles(
+	r Matrix,
+	s EulerSequence,
+	extrinsic bool,
+) EulerAngles {
+	axes := eulerSequenceAxes[s]
+	if extrinsic {
+		// an extrinsic sequence is the reversed intrinsic sequence, with the
+		// angles in reverse order
+		axes[0], axes[2] = axes[2], axes[0]
+	}
+
+	i, j := axes[0], axes[1]
+	k := 3 - i - j
+
+	// Permute the axes so that the sequence becomes X, Y, Z or X, Y, X. When the
+	// permutation is odd, the third axis is negated to keep a right-handed
+	// frame, which negates rotations about it.
+	sign := 1.0
+	if (j-i+3)%3 != 1 {
+		sign = -1
+	}
+	m := permuteMatrix(r, [3]int{i, j, k}, sign)
+
+	var first, second, third float64
+	if axes[0] == axes[2] {
+		first, second, third = properEulerXYX(m)
+	} else {
+		xyz := RotationMatrixToEulerXYZ(m)
+		first, second, third = xyz.X, xyz.Y, sign*xyz.Z
+	}
+
+	if extrinsic {
+		first, third = third, first
+	}
+
+	return EulerAngles{
+		Sequence:  s,
+		Extrinsic: extrinsic,
+		First:     first,
+		Second:    second,
+		Third:     third,
+	}
+}
+
+// properEulerXYX converts a rotation matrix to Euler angles in the intrinsic
+// sequence X, Y, X.
+func properEulerXYX(r Matrix) (x1, y, x2 float64) {
+	// sy is based on as many elements as possible, to average out numerical
+	// errors. It is selected as the positive square root since y: [0 pi]
+	sy := math.Sqrt((math.Pow(r.XY, 2) +
+		math.Pow(r.XZ, 2) +
+		math.Pow(r.YX, 2) +
+		math.Pow(r.ZX, 2)) / 2,
+	)
+
+	y = math.Atan2(sy, r.XX)
+
+	// Check if (close to) Euler angle singularity:
+	if sy > eulerThreshold {
+		// Outside singularity:
+		// atan2: [-pi pi]
+		x1 = math.Atan2(r.YX, -r.ZX)
+		x2 = math.Atan2(r.XY, r.XZ)
+	} else {
+		// In singularity (or close to), i.e. y = 0 or pi:
+		// Only the sum/difference of x1 and x2 is now given, choosing x1 = 0:
+		x1 = 0
+
+		// Lower right 2x2 elements of R_AB now only consist of sin_x2 and cos_x2:
+		x2 = math.Atan2(-r.YZ, r.YY)
+	}
+
+	return x1, y, x2
+}
+
+// permuteMatrix returns r with its axes permuted so that the axes p become the
+// x, y, and z axes, and the new z axis scaled by sign.
+func permuteMatrix(r Matrix, p [3]int, sign float64) Matrix {
+	a := [3][3]float64{
+		{r.XX, r.XY, r.XZ},
+		{r.YX, r.YY, r.YZ},
+		{r.ZX, r.ZY, r.ZZ},
+	}
+	s := [3]float64{1, 1, sign}
+
+	var m [3][3]float64
+	for row := range 3 {
+		for col := range 3 {
+			m[row][col] = s[row] * s[col] * a[p[row]][p[col]]
+		}
+	}
+
+	return Matrix{
+		m[0][0], m[0][1], m[0][2],
+		m[1][0], m[1][1], m[1][2],
+		m[2][0], m[2][1], m[2][2],
+	}
+}
+
+// axisRotationMatrix returns the rotation matrix for a rotation of a radians
+// about the axis with index i, where 0, 1, and 2 are the x, y, and z axes.
+func axisRotationMatrix(i int, a float64) Matrix {
+	var axis Vector
+	switch i {
+	case 0:
+		axis.X = 1
+	case 1:
+		axis.Y = 1
+	default:
+		axis.Z = 1
+	}
+
+	return AxisAngleToRotationMatrix(AxisAngle{axis, a})
+}
@@ -0,0 +1,264 @@
+package nvector_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+var eulerSequences = []EulerSequence{
+	EulerSequenceXYZ,
+	EulerSequenceXZY,
+	EulerSequenceYXZ,
+	EulerSequenceYZX,
+	EulerSequenceZXY,
+	EulerSequenceZYX,
+	EulerSequenceXYX,
+	EulerSequenceXZX,
+	EulerSequenceYXY,
+	EulerSequenceYZY,
+	EulerSequenceZXZ,
+	EulerSequenceZYZ,
+}
+
+func Test_EulerSequence(t *testing.T) {
+	tests := map[EulerSequence]struct {
+		name     string
+		isProper bool
+	}{
+		EulerSequenceXYZ: {"XYZ", false},
+		EulerSequenceZYX: {"ZYX", false},
+		EulerSequenceYZX: {"YZX", false},
+		EulerSequenceZXZ: {"ZXZ", true},
+		EulerSequenceYXY: {"YXY", true},
+	}
+
+	for s, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.String(); got != tt.name {
+				t.Errorf("got name %q; want %q", got, tt.name)
+			}
+			if got := s.IsProper(); got != tt.isProper {
+				t.Errorf("got IsProper %v; want %v", got, tt.isProper)
+			}
+		})
+	}
+}
+
+func Test_EulerAnglesToRotationMatrix(t *testing.T) {
+	t.Run("it matches EulerXYZToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := EulerXYZ{
+				X: rapidgen.Radians().Draw(t, "x"),
+				Y: rapidgen.Radians().Draw(t, "y"),
+				Z: rapidgen.Radians().Draw(t, "z"),
+			}
+
+			got := EulerAnglesToRotationMatrix(EulerAngles{
+				Sequence: EulerSequenceXYZ,
+				First:    a.X,
+				Second:   a.Y,
+				Third:    a.Z,
+			})
+
+			if eq, ineq := equality.EqualToMatrix(got, EulerXYZToRotationMatrix(a), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it matches EulerZYXToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := EulerZYX{
+				Z: rapidgen.Radians().Draw(t, "z"),
+				Y: rapidgen.Radians().Draw(t, "y"),
+				X: rapidgen.Radians().Draw(t, "x"),
+			}
+
+			got := EulerAnglesToRotationMatrix(EulerAngles{
+				Sequence: EulerSequenceZYX,
+				First:    a.Z,
+				Second:   a.Y,
+				Third:    a.X,
+			})
+
+			if eq, ineq := equality.EqualToMatrix(got, EulerZYXToRotationMatrix(a), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it treats extrinsic sequences as reversed intrinsic sequences", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			first := rapidgen.Radians().Draw(t, "first")
+			second := rapidgen.Radians().Draw(t, "second")
+			third := rapidgen.Radians().Draw(t, "third")
+
+			got := EulerAnglesToRotationMatrix(EulerAngles{
+				Sequence:  EulerSequenceXYZ,
+				Extrinsic: true,
+				First:     first,
+				Second:    second,
+				Third:     third,
+			})
+			want := EulerZYXToRotationMatrix(EulerZYX{Z: third, Y: second, X: first})
+
+			if eq, ineq := equality.EqualToMatrix(got, want, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it rotates about the axes of proper Euler sequences", func(t *testing.T) {
+		got := EulerAnglesToRotationMatrix(EulerAngles{
+			Sequence: EulerSequenceZXZ,
+			First:    math.Pi / 2,
+			Second:   math.Pi / 2,
+			Third:    0,
+		})
+
+		// Z by 90deg, then X by 90deg about the new X axis (old Y)
+		if eq, ineq := equality.EqualToVector(Vector{X: 1}.Transform(got), Vector{Y: 1}, 1e-15); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+		if eq, ineq := equality.EqualToVector(Vector{Y: 1}.Transform(got), Vector{Z: 1}, 1e-15); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+}
+
+func Test_RotationMatrixToEulerAngles(t *testing.T) {
+	t.Run("it matches RotationMatrixToEulerXYZ", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			got := RotationMatrixToEulerAngles(r, EulerSequenceXYZ, false)
+			want := RotationMatrixToEulerXYZ(r)
+
+			if eq, ineq := equality.EqualToEulerAnglesXYZ(
+				EulerXYZ{X: got.First, Y: got.Second, Z: got.Third},
+				want,
+				0,
+			); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it matches RotationMatrixToEulerZYX", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Filter(func(r Matrix) bool {
+				return math.Abs(r.ZX) < 0.999
+			}).Draw(t, "rotation")
+
+			got := RotationMatrixToEulerAngles(r, EulerSequenceZYX, false)
+			want := RotationMatrixToEulerZYX(r)
+
+			if eq, ineq := equality.EqualToEulerAnglesZYX(
+				EulerZYX{Z: got.First, Y: got.Second, X: got.Third},
+				want,
+				1e-12,
+			); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	for _, s := range eulerSequences {
+		for _, extrinsic := range []bool{false, true} {
+			name := fmt.Sprintf("%v intrinsic", s)
+			if extrinsic {
+				name = fmt.Sprintf("%v extrinsic", s)
+			}
+
+			t.Run(name, func(t *testing.T) {
+				t.Run("it is the inverse of EulerAnglesToRotationMatrix", func(t *testing.T) {
+					rapid.Check(t, func(t *rapid.T) {
+						r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+						a := RotationMatrixToEulerAngles(r, s, extrinsic)
+						if nearEulerSingularity(a) {
+							// skip rotations near the Euler angle singularity, where small
+							// errors in the first and third angles are magnified
+							t.Skip()
+						}
+						got := EulerAnglesToRotationMatrix(a)
+
+						if a.Sequence != s || a.Extrinsic != extrinsic {
+							t.Errorf("got sequence %v extrinsic %v; want %v %v", a.Sequence, a.Extrinsic, s, extrinsic)
+						}
+						if eq, ineq := equality.EqualToMatrix(got, r, 1e-13); !eq {
+							equality.ReportInequalities(t, ineq)
+						}
+					})
+				})
+
+				t.Run("it returns angles in range", func(t *testing.T) {
+					rapid.Check(t, func(t *rapid.T) {
+						r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+						got := RotationMatrixToEulerAngles(r, s, extrinsic)
+
+						minSecond, maxSecond := -math.Pi/2, math.Pi/2
+						if s.IsProper() {
+							minSecond, maxSecond = 0, math.Pi
+						}
+						if got.Second < minSecond || got.Second > maxSecond {
+							t.Errorf("got second angle %v; want [%v, %v]", got.Second, minSecond, maxSecond)
+						}
+						if math.Abs(got.First) > math.Pi || math.Abs(got.Third) > math.Pi {
+							t.Errorf("got first and third angles %v, %v; want [-pi, pi]", got.First, got.Third)
+						}
+					})
+				})
+
+				t.Run("it handles the singularity", func(t *testing.T) {
+					singular := []float64{-math.Pi / 2, math.Pi / 2}
+					if s.IsProper() {
+						singular = []float64{0, math.Pi}
+					}
+
+					rapid.Check(t, func(t *rapid.T) {
+						want := EulerAngles{
+							Sequence:  s,
+							Extrinsic: extrinsic,
+							First:     rapidgen.Radians().Draw(t, "first"),
+							Second:    rapid.SampledFrom(singular).Draw(t, "second"),
+							Third:     rapidgen.Radians().Draw(t, "third"),
+						}
+						r := EulerAnglesToRotationMatrix(want)
+
+						got := RotationMatrixToEulerAngles(r, s, extrinsic)
+
+						zeroed := got.First
+						if extrinsic {
+							zeroed = got.Third
+						}
+						if zeroed != 0 {
+							t.Errorf("got zeroed angle %v; want 0", zeroed)
+						}
+						if eq, ineq := equality.EqualToRadians(got.Second, want.Second, 1e-7); !eq {
+							equality.ReportInequality(t, "Second", ineq)
+						}
+						if eq, ineq := equality.EqualToMatrix(EulerAnglesToRotationMatrix(got), r, 1e-13); !eq {
+							equality.ReportInequalities(t, ineq)
+						}
+					})
+				})
+			})
+		}
+	}
+}
+
+func nearEulerSingularity(a EulerAngles) bool {
+	if a.Sequence.IsProper() {
+		return math.Abs(math.Sin(a.Second)) < 1e-3
+	}
+
+	return math.Abs(math.Cos(a.Second)) < 1e-3
+}
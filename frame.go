@@ -0,0 +1,108 @@
+package nvector
+
+// FrameE is the coordinate frame E (Earth-Centred, Earth-Fixed, ECEF).
+type FrameE struct{}
+
+// FrameN is the coordinate frame N (North-East-Down) of a position.
+type FrameN struct{}
+
+// FrameL is the coordinate frame L (local level, wander azimuth) of a position.
+type FrameL struct{}
+
+// FrameB is the coordinate frame B (body, forward, starboard, down) of a
+// vehicle.
+type FrameB struct{}
+
+// Frame is a coordinate frame in which vectors can be decomposed.
+//
+// Frames are used as type parameters of FrameVector and Rotation, so that
+// vectors decomposed in different frames cannot be mixed by accident.
+type Frame interface {
+	FrameE | FrameN | FrameL | FrameB
+}
+
+// FrameVector is a vector decomposed in the coordinate frame F.
+//
+// For example, the vector p_AB_E from the n-vector toolbox (the position of B
+// relative to A, decomposed in E) is a FrameVector[FrameE].
+type FrameVector[F Frame] struct {
+	Vector Vector
+}
+
+// Add returns v+w.
+func (v FrameVector[F]) Add(w FrameVector[F]) FrameVector[F] {
+	return FrameVector[F]{v.Vector.Add(w.Vector)}
+}
+
+// Cross returns the cross product of v and w.
+func (v FrameVector[F]) Cross(w FrameVector[F]) FrameVector[F] {
+	return FrameVector[F]{v.Vector.Cross(w.Vector)}
+}
+
+// Dot returns the dot product of v and w.
+func (v FrameVector[F]) Dot(w FrameVector[F]) float64 {
+	return v.Vector.Dot(w.Vector)
+}
+
+// Norm returns the Euclidean norm of v.
+func (v FrameVector[F]) Norm() float64 {
+	return v.Vector.Norm()
+}
+
+// Normalize returns a vector in the same direction as v but with norm 1.
+func (v FrameVector[F]) Normalize() FrameVector[F] {
+	return FrameVector[F]{v.Vector.Normalize()}
+}
+
+// Scale returns v scaled by s.
+func (v FrameVector[F]) Scale(s float64) FrameVector[F] {
+	return FrameVector[F]{v.Vector.Scale(s)}
+}
+
+// Sub returns v-w.
+func (v FrameVector[F]) Sub(w FrameVector[F]) FrameVector[F] {
+	return FrameVector[F]{v.Vector.Sub(w.Vector)}
+}
+
+// Rotation is the rotation matrix R_AB from the coordinate frame A to the
+// coordinate frame B.
+//
+// The columns of the matrix are the axes of B, decomposed in A. Transforming a
+// vector decomposed in B by R_AB gives the same vector decomposed in A. For
+// example, the matrix R_EN from the n-vector toolbox is a
+// Rotation[FrameE, FrameN].
+type Rotation[A, B Frame] struct {
+	Matrix Matrix
+}
+
+// Transform returns v, decomposed in A instead of B.
+func (r Rotation[A, B]) Transform(v FrameVector[B]) FrameVector[A] {
+	return FrameVector[A]{v.Vector.Transform(r.Matrix)}
+}
+
+// Transpose returns the inverse rotation R_BA.
+func (r Rotation[A, B]) Transpose() Rotation[B, A] {
+	return Rotation[B, A]{r.Matrix.Transpose()}
+}
+
+// Compose combines the rotations R_AB and R_BC to produce R_AC.
+func Compose[A, B, C Frame](ab Rotation[A, B], bc Rotation[B, C]) Rotation[A, C] {
+	return Rotation[A, C]{ab.Matrix.Multiply(bc.Matrix)}
+}
+
+// RotationEN converts an n-vector to the rotation R_EN, as described by
+// ToRotationMatrix.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func RotationEN(v FrameVector[FrameE], f Matrix) Rotation[FrameE, FrameN] {
+	return Rotation[FrameE, FrameN]{ToRotationMatrix(v.Vector, f)}
+}
+
+// RotationEL converts an n-vector and a wander azimuth angle to the rotation
+// R_EL, as described by ToRotationMatrixUsingWanderAzimuth.
+//
+// w is the wander azimuth angle in radians. f is the coordinate frame in which
+// the n-vector is decomposed.
+func RotationEL(v FrameVector[FrameE], w float64, f Matrix) Rotation[FrameE, FrameL] {
+	return Rotation[FrameE, FrameL]{ToRotationMatrixUsingWanderAzimuth(v.Vector, w, f)}
+}
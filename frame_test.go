@@ -0,0 +1,147 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Rotation_Transform(t *testing.T) {
+	t.Run("it matches Vector.Transform", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			m := rapidgen.RotationMatrix().Draw(t, "rotation")
+			v := rapidgen.VectorRange(-1e6, 1e6).Draw(t, "v")
+
+			got := Rotation[FrameN, FrameB]{m}.Transform(FrameVector[FrameB]{v})
+
+			if eq, ineq := equality.EqualToVector(got.Vector, v.Transform(m), 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it matches example 1", func(t *testing.T) {
+		a := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(1), Longitude: Radians(2)},
+				ZAxisNorth,
+			),
+			Depth: 3,
+		}
+		b := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(4), Longitude: Radians(5)},
+				ZAxisNorth,
+			),
+			Depth: 6,
+		}
+		want := Vector{X: 331730.23478089, Y: 332997.87498927, Z: 17404.27136194}
+
+		pABE := FrameVector[FrameE]{Delta(a, b, WGS84, ZAxisNorth)}
+		rEN := RotationEN(FrameVector[FrameE]{a.Vector}, ZAxisNorth)
+		pABN := rEN.Transpose().Transform(pABE)
+
+		if eq, ineq := equality.EqualToVector(pABN.Vector, want, 1e-8); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+}
+
+func Test_Rotation_Transpose(t *testing.T) {
+	t.Run("it undoes the rotation", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := Rotation[FrameE, FrameN]{rapidgen.RotationMatrix().Draw(t, "rotation")}
+			v := FrameVector[FrameN]{rapidgen.VectorRange(-1e6, 1e6).Draw(t, "v")}
+
+			got := r.Transpose().Transform(r.Transform(v))
+
+			if eq, ineq := equality.EqualToVector(got.Vector, v.Vector, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_Compose(t *testing.T) {
+	t.Run("it matches Matrix.Multiply", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			rEN := Rotation[FrameE, FrameN]{rapidgen.RotationMatrix().Draw(t, "rEN")}
+			rNB := Rotation[FrameN, FrameB]{rapidgen.RotationMatrix().Draw(t, "rNB")}
+
+			got := Compose(rEN, rNB)
+
+			if eq, ineq := equality.EqualToMatrix(got.Matrix, rEN.Matrix.Multiply(rNB.Matrix), 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it is equivalent to transforming in sequence", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			rEN := Rotation[FrameE, FrameN]{rapidgen.RotationMatrix().Draw(t, "rEN")}
+			rNB := Rotation[FrameN, FrameB]{rapidgen.RotationMatrix().Draw(t, "rNB")}
+			v := FrameVector[FrameB]{rapidgen.VectorRange(-1e6, 1e6).Draw(t, "v")}
+
+			got := Compose(rEN, rNB).Transform(v)
+			want := rEN.Transform(rNB.Transform(v))
+
+			if eq, ineq := equality.EqualToVector(got.Vector, want.Vector, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_RotationEL(t *testing.T) {
+	t.Run("it matches RotationEN when the wander azimuth is zero", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := FrameVector[FrameE]{rapidgen.UnitVector().Draw(t, "nVector")}
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			if v.Vector.Transform(f).X > 0.999 || v.Vector.Transform(f).X < -0.999 {
+				// skip n-vectors near the poles, where north is poorly defined
+				t.Skip()
+			}
+
+			got := RotationEL(v, 0, f)
+			want := RotationEN(v, f)
+
+			if eq, ineq := equality.EqualToMatrix(got.Matrix, want.Matrix, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_FrameVector(t *testing.T) {
+	t.Run("it matches the Vector operations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.VectorRange(-1e6, 1e6).Draw(t, "v")
+			w := rapidgen.VectorRange(-1e6, 1e6).Draw(t, "w")
+			s := rapid.Float64Range(-10, 10).Draw(t, "s")
+			fv := FrameVector[FrameE]{v}
+			fw := FrameVector[FrameE]{w}
+
+			if eq, ineq := equality.EqualToVector(fv.Add(fw).Vector, v.Add(w), 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToVector(fv.Sub(fw).Vector, v.Sub(w), 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToVector(fv.Cross(fw).Vector, v.Cross(w), 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToVector(fv.Scale(s).Vector, v.Scale(s), 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(fv.Dot(fw), v.Dot(w), 0); !eq {
+				equality.ReportInequality(t, "Dot", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(fv.Norm(), v.Norm(), 0); !eq {
+				equality.ReportInequality(t, "Norm", ineq)
+			}
+		})
+	})
+}
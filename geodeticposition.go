@@ -0,0 +1,64 @@
+package nvector
+
+// GeodeticPosition is a geodetic latitude, longitude, and height.
+//
+// Latitude and Longitude are given in radians. Height is given in meters above
+// the ellipsoid, and is the negative of the depth of a Position.
+type GeodeticPosition struct {
+	Latitude, Longitude, Height float64
+}
+
+// GeodeticCoordinatesFromDegrees returns geodetic coordinates from a latitude
+// and longitude in degrees.
+func GeodeticCoordinatesFromDegrees(lat, lon float64) GeodeticCoordinates {
+	return GeodeticCoordinates{Radians(lat), Radians(lon)}
+}
+
+// GeodeticPositionFromDegrees returns a geodetic position from a latitude and
+// longitude in degrees, and a height in meters above the ellipsoid.
+func GeodeticPositionFromDegrees(lat, lon, height float64) GeodeticPosition {
+	return GeodeticPosition{Radians(lat), Radians(lon), height}
+}
+
+// PositionFromDegrees returns the position at a latitude and longitude in
+// degrees, and a height in meters above the ellipsoid.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func PositionFromDegrees(lat, lon, height float64, f Matrix) Position {
+	return FromGeodeticPosition(GeodeticPositionFromDegrees(lat, lon, height), f)
+}
+
+// Coordinates returns the latitude and longitude of the position.
+func (p GeodeticPosition) Coordinates() GeodeticCoordinates {
+	return GeodeticCoordinates{p.Latitude, p.Longitude}
+}
+
+// FromGeodeticPosition converts a geodetic position to an n-vector and depth.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func FromGeodeticPosition(p GeodeticPosition, f Matrix) Position {
+	return Position{FromGeodeticCoordinates(p.Coordinates(), f), -p.Height}
+}
+
+// ToGeodeticPosition converts an n-vector and depth to a geodetic position.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToGeodeticPosition(p Position, f Matrix) GeodeticPosition {
+	c := ToGeodeticCoordinates(p.Vector, f)
+
+	return GeodeticPosition{c.Latitude, c.Longitude, -p.Depth}
+}
+
+// GeodeticToECEF converts a geodetic position to an ECEF position vector.
+//
+// f is the coordinate frame in which the vector is decomposed.
+func GeodeticToECEF(p GeodeticPosition, e Ellipsoid, f Matrix) Vector {
+	return ToECEF(FromGeodeticPosition(p, f), e, f)
+}
+
+// ECEFToGeodetic converts an ECEF position vector to a geodetic position.
+//
+// f is the coordinate frame in which the vector is decomposed.
+func ECEFToGeodetic(v Vector, e Ellipsoid, f Matrix) GeodeticPosition {
+	return ToGeodeticPosition(FromECEF(v, e, f), f)
+}
@@ -0,0 +1,150 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+// geodeticPosition creates a rapid generator for geodetic positions away from
+// the poles, where longitude is undefined.
+func geodeticPosition() *rapid.Generator[GeodeticPosition] {
+	return rapid.Custom(func(t *rapid.T) GeodeticPosition {
+		return GeodeticPositionFromDegrees(
+			rapid.Float64Range(-89.9, 89.9).Draw(t, "latitude"),
+			rapid.Float64Range(-179.9, 179.9).Draw(t, "longitude"),
+			rapid.Float64Range(-1e4, 1e5).Draw(t, "height"),
+		)
+	})
+}
+
+func Test_FromGeodeticPosition(t *testing.T) {
+	t.Run("it matches the n-vector and the negated height", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := geodeticPosition().Draw(t, "position")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := FromGeodeticPosition(p, f)
+			want := Position{
+				Vector: FromGeodeticCoordinates(p.Coordinates(), f),
+				Depth:  -p.Height,
+			}
+
+			if eq, ineq := equality.EqualToVector(got.Vector, want.Vector, 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Depth, want.Depth, 0); !eq {
+				equality.ReportInequality(t, "Depth", ineq)
+			}
+		})
+	})
+}
+
+func Test_ToGeodeticPosition(t *testing.T) {
+	t.Run("it is the inverse of FromGeodeticPosition", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := geodeticPosition().Draw(t, "position")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := ToGeodeticPosition(FromGeodeticPosition(p, f), f)
+
+			if eq, ineq := equality.EqualToRadians(got.Latitude, p.Latitude, 1e-14); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+			// longitude is ill-conditioned near the poles
+			if eq, ineq := equality.EqualToRadians(got.Longitude, p.Longitude, 1e-12); !eq {
+				equality.ReportInequality(t, "Longitude", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Height, p.Height, 0); !eq {
+				equality.ReportInequality(t, "Height", ineq)
+			}
+		})
+	})
+}
+
+func Test_GeodeticToECEF(t *testing.T) {
+	t.Run("it converts known positions", func(t *testing.T) {
+		b := WGS84.SemiMajorAxis * (1 - WGS84.Flattening)
+
+		tests := map[string]struct {
+			p    GeodeticPosition
+			want Vector
+		}{
+			"equator and prime meridian": {
+				GeodeticPositionFromDegrees(0, 0, 0),
+				Vector{X: WGS84.SemiMajorAxis},
+			},
+			"equator and 90 degrees east, above the ellipsoid": {
+				GeodeticPositionFromDegrees(0, 90, 100),
+				Vector{Y: WGS84.SemiMajorAxis + 100},
+			},
+			"north pole, below the ellipsoid": {
+				GeodeticPositionFromDegrees(90, 0, -100),
+				Vector{Z: b - 100},
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				got := GeodeticToECEF(tt.p, WGS84, ZAxisNorth)
+
+				if eq, ineq := equality.EqualToVector(got, tt.want, 1e-8); !eq {
+					equality.ReportInequalities(t, ineq)
+				}
+			})
+		}
+	})
+}
+
+func Test_ECEFToGeodetic(t *testing.T) {
+	t.Run("it is the inverse of GeodeticToECEF", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := geodeticPosition().Draw(t, "position")
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := ECEFToGeodetic(GeodeticToECEF(p, e, f), e, f)
+
+			if eq, ineq := equality.EqualToRadians(got.Latitude, p.Latitude, 1e-12); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(got.Longitude, p.Longitude, 1e-12); !eq {
+				equality.ReportInequality(t, "Longitude", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Height, p.Height, 1e-7); !eq {
+				equality.ReportInequality(t, "Height", ineq)
+			}
+		})
+	})
+}
+
+func Test_PositionFromDegrees(t *testing.T) {
+	t.Run("it matches FromGeodeticPosition", func(t *testing.T) {
+		got := PositionFromDegrees(1, 2, 3, ZAxisNorth)
+		want := FromGeodeticPosition(
+			GeodeticPosition{Latitude: Radians(1), Longitude: Radians(2), Height: 3},
+			ZAxisNorth,
+		)
+
+		if eq, ineq := equality.EqualToVector(got.Vector, want.Vector, 0); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Depth, -3, 0); !eq {
+			equality.ReportInequality(t, "Depth", ineq)
+		}
+	})
+}
+
+func Test_GeodeticCoordinatesFromDegrees(t *testing.T) {
+	got := GeodeticCoordinatesFromDegrees(45, -90)
+
+	if eq, ineq := equality.EqualToRadians(got.Latitude, Radians(45), 0); !eq {
+		equality.ReportInequality(t, "Latitude", ineq)
+	}
+	if eq, ineq := equality.EqualToRadians(got.Longitude, Radians(-90), 0); !eq {
+		equality.ReportInequality(t, "Longitude", ineq)
+	}
+}
@@ -0,0 +1,363 @@
+package nvector
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Geoid is a model of the geoid, the equipotential surface that approximates
+// mean sea level.
+type Geoid interface {
+	// Undulation returns the height of the geoid above the ellipsoid at the
+	// position of the n-vector v, in meters. f is the coordinate frame in which
+	// the n-vector is decomposed.
+	Undulation(v Vector, f Matrix) (float64, error)
+}
+
+// ToOrthometricHeight returns the height of the position p above the geoid g,
+// in meters, which approximates the height above mean sea level. f is the
+// coordinate frame in which the n-vector is decomposed.
+func ToOrthometricHeight(p Position, g Geoid, f Matrix) (float64, error) {
+	n, err := g.Undulation(p.Vector, f)
+	if err != nil {
+		return 0, err
+	}
+
+	return -p.Depth - n, nil
+}
+
+// FromOrthometricHeight returns the position of the n-vector v at height h
+// above the geoid g, in meters, with a depth relative to the ellipsoid. f is
+// the coordinate frame in which the n-vector is decomposed.
+func FromOrthometricHeight(v Vector, h float64, g Geoid, f Matrix) (Position, error) {
+	n, err := g.Undulation(v, f)
+	if err != nil {
+		return Position{}, err
+	}
+
+	return Position{v, -h - n}, nil
+}
+
+// GeoidInterpolation is a method of interpolating between the nodes of a
+// GeoidGrid.
+type GeoidInterpolation int
+
+const (
+	// GeoidBilinear interpolates linearly between the four surrounding nodes.
+	GeoidBilinear GeoidInterpolation = iota
+	// GeoidBicubic interpolates with cubic convolution between the sixteen
+	// surrounding nodes, which is smooth across the cell boundaries.
+	GeoidBicubic
+)
+
+// GeoidGrid is a geoid model defined by undulations at the nodes of a regular
+// grid of latitudes and longitudes, such as the EGM96 and EGM2008 grids.
+//
+// If the grid covers all longitudes, interpolation wraps around the
+// antimeridian. Otherwise, Undulation returns an error outside the grid.
+type GeoidGrid struct {
+	// North is the latitude of the first row, in radians.
+	North float64
+	// West is the longitude of the first column, in radians.
+	West float64
+	// LatitudeSpacing is the latitude difference between rows, in radians.
+	// Rows are ordered from north to south.
+	LatitudeSpacing float64
+	// LongitudeSpacing is the longitude difference between columns, in
+	// radians. Columns are ordered from west to east.
+	LongitudeSpacing float64
+	// Rows is the number of rows.
+	Rows int
+	// Columns is the number of columns.
+	Columns int
+	// Undulations are the heights of the geoid above the ellipsoid at each
+	// node, in meters, ordered by row and then by column.
+	Undulations []float64
+	// Interpolation is the method of interpolating between nodes.
+	Interpolation GeoidInterpolation
+}
+
+// LoadGeoidGrid loads a geoid grid from the file at path, as described by
+// ReadGeoidGrid.
+func LoadGeoidGrid(path string) (GeoidGrid, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return GeoidGrid{}, err
+	}
+	defer file.Close()
+
+	return ReadGeoidGrid(file)
+}
+
+// ReadGeoidGrid reads a geoid grid in one of the following formats:
+//
+//   - The ASCII grid format used by the NGA for the EGM96 and EGM2008 grids,
+//     e.g. WW15MGH.GRD. The header contains the south, north, west, and east
+//     bounds, and the latitude and longitude spacing, in degrees. The
+//     undulations follow, from north to south and west to east.
+//   - The PGM format used by GeographicLib, e.g. egm96-5.pgm and
+//     egm2008-1.pgm, which covers the whole globe with 16-bit undulations,
+//     scaled by the Offset and Scale comments in the header.
+//
+// The grid is interpolated bilinearly. Set the Interpolation field to use
+// another method.
+//
+// See: https://earth-info.nga.mil/index.php?dir=wgs84&action=wgs84
+//
+// See: https://geographiclib.sourceforge.io/C++/doc/geoid.html
+func ReadGeoidGrid(r io.Reader) (GeoidGrid, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(2)
+	if err == nil && string(magic) == "P5" {
+		return readGeoidPGM(br)
+	}
+
+	return readGeoidGRD(br)
+}
+
+// Undulation returns the height of the geoid above the ellipsoid at the
+// position of the n-vector v, in meters, interpolated from the grid. f is the
+// coordinate frame in which the n-vector is decomposed.
+func (g GeoidGrid) Undulation(v Vector, f Matrix) (float64, error) {
+	c := ToGeodeticCoordinates(v, f)
+
+	y := (g.North - c.Latitude) / g.LatitudeSpacing
+
+	// longitude east of the first column, in [0, 2pi)
+	d := math.Mod(c.Longitude-g.West, 2*math.Pi)
+	if d < 0 {
+		d += 2 * math.Pi
+	}
+	x := d / g.LongitudeSpacing
+
+	// allow for rounding at the edges of the grid
+	const eps = 1e-9
+	if x > 2*math.Pi/g.LongitudeSpacing-eps {
+		x = 0
+	}
+	inLatitude := y > -eps && y < float64(g.Rows-1)+eps
+	inLongitude := g.wraps() || x < float64(g.Columns-1)+eps
+
+	if !inLatitude || !inLongitude {
+		return 0, fmt.Errorf(
+			"coordinates (%v, %v) are outside the geoid grid",
+			Degrees(c.Latitude),
+			Degrees(c.Longitude),
+		)
+	}
+
+	y = math.Max(0, math.Min(y, float64(g.Rows-1)))
+	if !g.wraps() {
+		x = math.Min(x, float64(g.Columns-1))
+	}
+
+	row, col := int(math.Floor(y)), int(math.Floor(x))
+	dy, dx := y-float64(row), x-float64(col)
+
+	if g.Interpolation == GeoidBicubic {
+		var rows [4]float64
+		for i := range 4 {
+			var nodes [4]float64
+			for j := range 4 {
+				nodes[j] = g.node(row-1+i, col-1+j)
+			}
+			rows[i] = cubicConvolution(nodes, dx)
+		}
+
+		return cubicConvolution(rows, dy), nil
+	}
+
+	n00, n01 := g.node(row, col), g.node(row, col+1)
+	n10, n11 := g.node(row+1, col), g.node(row+1, col+1)
+
+	return (1-dy)*((1-dx)*n00+dx*n01) + dy*((1-dx)*n10+dx*n11), nil
+}
+
+// wraps returns true if the grid covers all longitudes.
+func (g GeoidGrid) wraps() bool {
+	return math.Abs(float64(g.Columns)*g.LongitudeSpacing-2*math.Pi) < 1e-9
+}
+
+// node returns the undulation at the node in row i and column j. Columns wrap
+// around the antimeridian if the grid covers all longitudes. Nodes otherwise
+// beyond the grid are extrapolated linearly from the nearest two nodes, so that
+// bicubic interpolation reproduces linear variation up to the edges.
+func (g GeoidGrid) node(i, j int) float64 {
+	switch {
+	case i < 0:
+		return 2*g.node(0, j) - g.node(1, j)
+	case i >= g.Rows:
+		return 2*g.node(g.Rows-1, j) - g.node(g.Rows-2, j)
+	}
+
+	if g.wraps() {
+		j %= g.Columns
+		if j < 0 {
+			j += g.Columns
+		}
+	} else {
+		switch {
+		case j < 0:
+			return 2*g.node(i, 0) - g.node(i, 1)
+		case j >= g.Columns:
+			return 2*g.node(i, g.Columns-1) - g.node(i, g.Columns-2)
+		}
+	}
+
+	return g.Undulations[i*g.Columns+j]
+}
+
+// cubicConvolution interpolates between p[1] and p[2] at the fraction t, using
+// Keys' cubic convolution kernel.
+//
+// See: https://doi.org/10.1109/TASSP.1981.1163711
+func cubicConvolution(p [4]float64, t float64) float64 {
+	return p[1] + 0.5*t*(p[2]-p[0]+
+		t*(2*p[0]-5*p[1]+4*p[2]-p[3]+
+			t*(3*(p[1]-p[2])+p[3]-p[0])))
+}
+
+// readGeoidGRD reads a geoid grid in the NGA ASCII grid format.
+func readGeoidGRD(r io.Reader) (GeoidGrid, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return GeoidGrid{}, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 6 {
+		return GeoidGrid{}, errors.New("geoid grid header is incomplete")
+	}
+
+	var header [6]float64
+	for i := range header {
+		header[i], err = strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return GeoidGrid{}, fmt.Errorf("invalid geoid grid header: %w", err)
+		}
+	}
+
+	south, north, west, east, dlat, dlon := header[0], header[1], header[2],
+		header[3], header[4], header[5]
+	if !(dlat > 0 && dlon > 0 && north > south && east > west) {
+		return GeoidGrid{}, fmt.Errorf("invalid geoid grid bounds %v", header)
+	}
+
+	rows := int(math.Round((north-south)/dlat)) + 1
+	columns := int(math.Round((east-west)/dlon)) + 1
+	if want := rows * columns; len(fields)-6 != want {
+		return GeoidGrid{}, fmt.Errorf(
+			"geoid grid has %d undulations, want %d",
+			len(fields)-6,
+			want,
+		)
+	}
+
+	// the last column of a global grid repeats the first
+	global := math.Abs(east-west-360) < 1e-9
+	stored := columns
+	if global {
+		stored--
+	}
+
+	undulations := make([]float64, 0, rows*stored)
+	for i := range rows {
+		for j := range stored {
+			n, err := strconv.ParseFloat(fields[6+i*columns+j], 64)
+			if err != nil {
+				return GeoidGrid{}, fmt.Errorf("invalid geoid undulation: %w", err)
+			}
+
+			undulations = append(undulations, n)
+		}
+	}
+
+	return GeoidGrid{
+		North:            Radians(north),
+		West:             Radians(west),
+		LatitudeSpacing:  Radians(dlat),
+		LongitudeSpacing: Radians(dlon),
+		Rows:             rows,
+		Columns:          stored,
+		Undulations:      undulations,
+	}, nil
+}
+
+// readGeoidPGM reads a geoid grid in the GeographicLib PGM format.
+func readGeoidPGM(r *bufio.Reader) (GeoidGrid, error) {
+	offset, scale := math.NaN(), math.NaN()
+
+	// the header is the magic number, width, height, and maximum value,
+	// separated by whitespace and interspersed with comments
+	var tokens []string
+	for len(tokens) < 4 {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return GeoidGrid{}, fmt.Errorf("geoid PGM header is incomplete: %w", err)
+		}
+
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			key, value, _ := strings.Cut(strings.TrimSpace(comment), " ")
+			switch key {
+			case "Offset":
+				offset, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+			case "Scale":
+				scale, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+			}
+			if err != nil {
+				return GeoidGrid{}, fmt.Errorf("invalid geoid PGM %s: %w", key, err)
+			}
+
+			continue
+		}
+
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+
+	if len(tokens) != 4 || tokens[0] != "P5" || tokens[3] != "65535" {
+		return GeoidGrid{}, fmt.Errorf("unsupported geoid PGM header %q", tokens)
+	}
+	if math.IsNaN(offset) || math.IsNaN(scale) {
+		return GeoidGrid{}, errors.New("geoid PGM header is missing Offset or Scale")
+	}
+
+	width, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return GeoidGrid{}, fmt.Errorf("invalid geoid PGM width: %w", err)
+	}
+	height, err := strconv.Atoi(tokens[2])
+	if err != nil {
+		return GeoidGrid{}, fmt.Errorf("invalid geoid PGM height: %w", err)
+	}
+	if width < 1 || height < 2 {
+		return GeoidGrid{}, fmt.Errorf("invalid geoid PGM size %dx%d", width, height)
+	}
+
+	raw := make([]uint16, width*height)
+	if err := binary.Read(r, binary.BigEndian, raw); err != nil {
+		return GeoidGrid{}, fmt.Errorf("geoid PGM data is incomplete: %w", err)
+	}
+
+	undulations := make([]float64, len(raw))
+	for i, n := range raw {
+		undulations[i] = offset + scale*float64(n)
+	}
+
+	return GeoidGrid{
+		North:            math.Pi / 2,
+		West:             0,
+		LatitudeSpacing:  math.Pi / float64(height-1),
+		LongitudeSpacing: 2 * math.Pi / float64(width),
+		Rows:             height,
+		Columns:          width,
+		Undulations:      undulations,
+	}, nil
+}
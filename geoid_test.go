@@ -0,0 +1,287 @@
+package nvector_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_GeoidGrid(t *testing.T) {
+	at := func(lat, lon float64) Vector {
+		return FromGeodeticCoordinates(
+			GeodeticCoordinates{Latitude: Radians(lat), Longitude: Radians(lon)},
+			ZAxisNorth,
+		)
+	}
+
+	t.Run("NGA ASCII grid", func(t *testing.T) {
+		g, err := LoadGeoidGrid("testdata/geoid/regional.grd")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the synthetic grid is linear in latitude and longitude
+		want := func(lat, lon float64) float64 {
+			return 45 + 0.5*lat + 0.25*lon
+		}
+
+		t.Run("it reads the grid", func(t *testing.T) {
+			if g.Rows != 5 || g.Columns != 4 {
+				t.Errorf("got %dx%d grid; want 5x4", g.Rows, g.Columns)
+			}
+			if eq, ineq := equality.EqualToRadians(g.North, Radians(52), 0); !eq {
+				equality.ReportInequality(t, "North", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(g.West, Radians(-2), 0); !eq {
+				equality.ReportInequality(t, "West", ineq)
+			}
+		})
+
+		for name, interpolation := range map[string]GeoidInterpolation{
+			"bilinear": GeoidBilinear,
+			"bicubic":  GeoidBicubic,
+		} {
+			t.Run(name, func(t *testing.T) {
+				g := g
+				g.Interpolation = interpolation
+
+				t.Run("it returns the undulations at the nodes", func(t *testing.T) {
+					for _, lat := range []float64{50, 50.5, 51, 51.5, 52} {
+						for _, lon := range []float64{-2, -1, 0, 1} {
+							got, err := g.Undulation(at(lat, lon), ZAxisNorth)
+							if err != nil {
+								t.Fatal(err)
+							}
+
+							if eq, ineq := equality.EqualToFloat64(got, want(lat, lon), 1e-12); !eq {
+								equality.ReportInequality(t, "Undulation", ineq)
+							}
+						}
+					}
+				})
+
+				t.Run("it interpolates between the nodes", func(t *testing.T) {
+					rapid.Check(t, func(t *rapid.T) {
+						lat := rapid.Float64Range(50, 52).Draw(t, "latitude")
+						lon := rapid.Float64Range(-2, 1).Draw(t, "longitude")
+
+						got, err := g.Undulation(at(lat, lon), ZAxisNorth)
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						if eq, ineq := equality.EqualToFloat64(got, want(lat, lon), 1e-12); !eq {
+							equality.ReportInequality(t, "Undulation", ineq)
+						}
+					})
+				})
+			})
+		}
+
+		t.Run("it returns an error outside the grid", func(t *testing.T) {
+			tests := map[string]Vector{
+				"north": at(53, 0),
+				"south": at(49, 0),
+				"west":  at(51, -3),
+				"east":  at(51, 2),
+				"other": at(-51, 179),
+			}
+
+			for name, v := range tests {
+				t.Run(name, func(t *testing.T) {
+					if _, err := g.Undulation(v, ZAxisNorth); err == nil {
+						t.Error("expected an error")
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("GeographicLib PGM grid", func(t *testing.T) {
+		g, err := LoadGeoidGrid("testdata/geoid/global.pgm")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the synthetic grid has raw values of 1000 times the node index
+		node := func(i, j int) float64 {
+			return -108 + 0.003*float64((i*8+j)*1000)
+		}
+
+		t.Run("it reads the grid", func(t *testing.T) {
+			if g.Rows != 5 || g.Columns != 8 {
+				t.Errorf("got %dx%d grid; want 5x8", g.Rows, g.Columns)
+			}
+		})
+
+		t.Run("it returns the undulations at the nodes", func(t *testing.T) {
+			for i := range 5 {
+				for j := range 8 {
+					got, err := g.Undulation(at(90-45*float64(i), 45*float64(j)), ZAxisNorth)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if eq, ineq := equality.EqualToFloat64(got, node(i, j), 1e-12); !eq {
+						equality.ReportInequality(t, "Undulation", ineq)
+					}
+				}
+			}
+		})
+
+		t.Run("it wraps around the antimeridian", func(t *testing.T) {
+			got, err := g.Undulation(at(45, -22.5), ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := (node(1, 7) + node(1, 0)) / 2
+
+			if eq, ineq := equality.EqualToFloat64(got, want, 1e-12); !eq {
+				equality.ReportInequality(t, "Undulation", ineq)
+			}
+		})
+
+		t.Run("it is defined everywhere", func(t *testing.T) {
+			for _, interpolation := range []GeoidInterpolation{GeoidBilinear, GeoidBicubic} {
+				g := g
+				g.Interpolation = interpolation
+
+				rapid.Check(t, func(t *rapid.T) {
+					v := rapidgen.UnitVector().Draw(t, "vector")
+					f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+					got, err := g.Undulation(v.Transform(f.Transpose().Multiply(ZAxisNorth)), f)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if math.IsNaN(got) || math.IsInf(got, 0) {
+						t.Errorf("got undulation %v; want a finite value", got)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("it drops the repeated column of global NGA grids", func(t *testing.T) {
+		s := "-90 90 0 360 90 180\n" +
+			"1 2 1\n" +
+			"3 4 3\n" +
+			"5 6 5\n"
+
+		g, err := ReadGeoidGrid(strings.NewReader(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g.Columns != 2 {
+			t.Errorf("got %d columns; want 2", g.Columns)
+		}
+
+		got, err := g.Undulation(at(0, -90), ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got, 3.5, 1e-12); !eq {
+			equality.ReportInequality(t, "Undulation", ineq)
+		}
+	})
+
+	t.Run("it returns an error for invalid grids", func(t *testing.T) {
+		tests := map[string]string{
+			"empty":               "",
+			"incomplete header":   "50 52 -2",
+			"invalid header":      "50 52 -2 1 0.5 x\n1",
+			"zero spacing":        "50 52 -2 1 0 1\n1",
+			"single row":          "50 50 -2 1 1 1\n1 2 3 4",
+			"missing undulations": "50 52 -2 1 1 1\n1 2 3 4",
+			"invalid undulation":  "50 51 -2 -1 1 1\n1 2 3 x",
+			"PGM without scale":   "P5\n# Offset -108\n1 2\n65535\n\x00\x00\x00\x00",
+			"PGM with 8 bits":     "P5\n# Offset -108\n# Scale 0.003\n1 2\n255\n\x00\x00",
+			"truncated PGM":       "P5\n# Offset -108\n# Scale 0.003\n2 2\n65535\n\x00\x00",
+		}
+
+		for name, s := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := ReadGeoidGrid(strings.NewReader(s)); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+
+	t.Run("it returns an error for missing files", func(t *testing.T) {
+		if _, err := LoadGeoidGrid("testdata/geoid/missing.grd"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_OrthometricHeight(t *testing.T) {
+	g := GeoidGrid{
+		North:            math.Pi / 2,
+		LatitudeSpacing:  math.Pi,
+		LongitudeSpacing: math.Pi,
+		Rows:             2,
+		Columns:          2,
+		Undulations:      []float64{30, 30, 30, 30},
+	}
+
+	t.Run("it subtracts the undulation from the ellipsoidal height", func(t *testing.T) {
+		p := Position{Vector: Vector{X: 1}, Depth: -100}
+
+		got, err := ToOrthometricHeight(p, g, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got, 70, 0); !eq {
+			equality.ReportInequality(t, "Height", ineq)
+		}
+	})
+
+	t.Run("it round trips", func(t *testing.T) {
+		grid, err := LoadGeoidGrid("testdata/geoid/global.pgm")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.UnitVector().Draw(t, "vector")
+			h := rapid.Float64Range(-1e4, 1e4).Draw(t, "height")
+
+			p, err := FromOrthometricHeight(v, h, grid, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ToOrthometricHeight(p, grid, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToFloat64(got, h, 1e-9); !eq {
+				equality.ReportInequality(t, "Height", ineq)
+			}
+		})
+	})
+
+	t.Run("it returns errors from the geoid", func(t *testing.T) {
+		regional, err := LoadGeoidGrid("testdata/geoid/regional.grd")
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := Vector{X: 1}
+
+		if _, err := ToOrthometricHeight(Position{Vector: v}, regional, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+		if _, err := FromOrthometricHeight(v, 0, regional, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
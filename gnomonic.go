@@ -0,0 +1,91 @@
+package nvector
+
+import (
+	"errors"
+	"math"
+)
+
+// Gnomonic is a spherical gnomonic projection centered on a position.
+//
+// Positions are projected from the center of a sphere onto the plane tangent to
+// the sphere at the center of the projection, so that all great circles are
+// projected as straight lines. Only the hemisphere centered on the center of
+// the projection can be projected.
+//
+// The projected y-axis points north from the center. If the center is at a
+// pole, north is the direction of the x-axis of the N frame returned by
+// ToRotationMatrix.
+//
+// See: https://en.wikipedia.org/wiki/Gnomonic_projection
+type Gnomonic struct {
+	// Radius is the radius of the sphere in meters.
+	Radius float64
+	// Center is the n-vector at the center of the projection.
+	Center Vector
+	// FalseEasting and FalseNorthing are the projected coordinates of the
+	// center, in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the n-vector is 90° or more away from the center,
+// outside the hemisphere that can be projected.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p Gnomonic) Forward(v Vector, f Matrix) (ProjectedCoordinates, error) {
+	n := v.Transform(ToRotationMatrix(p.Center, f).Transpose())
+	if n.Z >= 0 {
+		return ProjectedCoordinates{}, errors.New(
+			"n-vector is outside the hemisphere centered on the projection",
+		)
+	}
+
+	return ProjectedCoordinates{
+		p.FalseEasting + p.Radius*n.Y/-n.Z,
+		p.FalseNorthing + p.Radius*n.X/-n.Z,
+	}, nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p Gnomonic) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	x := (c.Easting - p.FalseEasting) / p.Radius
+	y := (c.Northing - p.FalseNorthing) / p.Radius
+
+	return Vector{y, x, -1}.
+		Normalize().
+		Transform(ToRotationMatrix(p.Center, f)), nil
+}
+
+// Distortion returns the scale factors and meridian convergence of the
+// projection at an n-vector.
+//
+// An error is returned if the n-vector is outside the hemisphere that can be
+// projected.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p Gnomonic) Distortion(v Vector, f Matrix) (Distortion, error) {
+	n := v.Transform(ToRotationMatrix(p.Center, f).Transpose())
+	if n.Z >= 0 {
+		return Distortion{}, errors.New(
+			"n-vector is outside the hemisphere centered on the projection",
+		)
+	}
+	if n.X == 0 && n.Y == 0 {
+		return Distortion{1, 1, 0}, nil
+	}
+
+	cosC := -n.Z
+
+	return azimuthalDistortion(
+		1/(cosC*cosC),
+		1/cosC,
+		math.Atan2(n.Y, n.X),
+		awayFrom(p.Center, v, f),
+	), nil
+}
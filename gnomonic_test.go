@@ -0,0 +1,183 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Gnomonic_Forward(t *testing.T) {
+	t.Run("it projects distances from the center as tangents", func(t *testing.T) {
+		p := Gnomonic{
+			Radius:        6371e3,
+			Center:        FromGeodeticCoordinates(GeodeticCoordinates{Radians(45), 0}, ZAxisNorth),
+			FalseEasting:  1000,
+			FalseNorthing: 2000,
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(75), 0},
+			ZAxisNorth,
+		)
+
+		got, err := p.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.Easting, 1000, 1e-6); !eq {
+			equality.ReportInequality(t, "Easting", ineq)
+		}
+		want := 2000 + 6371e3*math.Tan(Radians(30))
+		if eq, ineq := equality.EqualToFloat64(got.Northing, want, 1e-6); !eq {
+			equality.ReportInequality(t, "Northing", ineq)
+		}
+	})
+
+	t.Run("it projects great circles as straight lines", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			p := Gnomonic{
+				Radius: 6371e3,
+				Center: rapidgen.UnitVector().Draw(t, "center"),
+			}
+			a := rapidgen.UnitVector().Draw(t, "a")
+			b := rapidgen.UnitVector().Draw(t, "b")
+			s := rapid.Float64Range(0, 1).Draw(t, "s")
+			m := a.Scale(1 - s).Add(b.Scale(s)).Normalize()
+			if a.Dot(p.Center) < 0.1 || b.Dot(p.Center) < 0.1 {
+				// skip n-vectors near the edge of the hemisphere, which project to
+				// very large coordinates
+				t.Skip()
+			}
+
+			pa, err := p.Forward(a, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pb, err := p.Forward(b, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pm, err := p.Forward(m, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The distance of m from the line through a and b:
+			ab := math.Hypot(pb.Easting-pa.Easting, pb.Northing-pa.Northing)
+			if ab < 1 {
+				// skip lines that are too short to define a direction
+				t.Skip()
+			}
+			d := ((pb.Easting-pa.Easting)*(pm.Northing-pa.Northing) -
+				(pb.Northing-pa.Northing)*(pm.Easting-pa.Easting)) / ab
+			scale := max(
+				p.Radius,
+				math.Hypot(pa.Easting, pa.Northing),
+				math.Hypot(pb.Easting, pb.Northing),
+			)
+
+			if eq, ineq := equality.EqualToFloat64(d, 0, 1e-12*scale); !eq {
+				equality.ReportInequality(t, "distance from line", ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error outside the hemisphere", func(t *testing.T) {
+		p := Gnomonic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+
+		for _, lon := range []float64{91, 120, 180} {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{0, Radians(lon)},
+				ZAxisNorth,
+			)
+
+			if _, err := p.Forward(v, ZAxisNorth); err == nil {
+				t.Errorf("longitude %v: expected an error", lon)
+			}
+		}
+	})
+}
+
+func Test_Gnomonic_Inverse(t *testing.T) {
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			p := Gnomonic{
+				Radius:        6371e3,
+				Center:        rapidgen.UnitVector().Draw(t, "center"),
+				FalseEasting:  rapid.Float64Range(-1e6, 1e6).Draw(t, "falseEasting"),
+				FalseNorthing: rapid.Float64Range(-1e6, 1e6).Draw(t, "falseNorthing"),
+			}
+			v := rapidgen.UnitVector().Draw(t, "v")
+			if v.Dot(p.Center) < 0.01 {
+				// skip n-vectors near the edge of the hemisphere, which project to
+				// very large coordinates
+				t.Skip()
+			}
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_Gnomonic_Distortion(t *testing.T) {
+	t.Run("it increases scale away from the center", func(t *testing.T) {
+		p := Gnomonic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(45)},
+			ZAxisNorth,
+		)
+
+		got, err := p.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, math.Sqrt2, 1e-15); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 2, 1e-15); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 1e-15); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it returns an error outside the hemisphere", func(t *testing.T) {
+		p := Gnomonic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(120)},
+			ZAxisNorth,
+		)
+
+		if _, err := p.Distortion(v, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
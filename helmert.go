@@ -0,0 +1,122 @@
+package nvector
+
+// Helmert is a seven-parameter Helmert transformation between ECEF frames,
+// consisting of a translation, a rotation, and a scale change.
+//
+// The rotations use the position vector convention (EPSG method 1033, and the
+// +towgs84 parameter of PROJ). Parameters published in the coordinate frame
+// convention (EPSG method 1032) must have the signs of their rotations
+// reversed.
+//
+// The parameters are defined for ECEF vectors with the z-axis pointing to the
+// North Pole, and the x-axis pointing to 0° latitude and longitude, i.e. the
+// axes selected by ZAxisNorth.
+//
+// See: https://epsg.org/guidance-notes.html
+type Helmert struct {
+	// TX, TY, and TZ are the translations in meters.
+	TX, TY, TZ float64
+	// RX, RY, and RZ are the rotations in radians about the x, y, and z axes.
+	RX, RY, RZ float64
+	// Scale is the scale change, e.g. 1e-6 for 1 part per million.
+	Scale float64
+}
+
+// Transform returns the ECEF position vector v transformed by h. The
+// coordinate frame f specifies the axes in which v, and the returned vector,
+// are decomposed.
+//
+// As is usual for Helmert transformations, the rotations are assumed to be
+// small enough that the rotation matrix can be linearized.
+func (h Helmert) Transform(v Vector, f Matrix) Vector {
+	// m changes the axes from f to ZAxisNorth
+	m := ZAxisNorth.Transpose().Multiply(f)
+
+	return h.translation().
+		Add(v.Transform(m).Transform(h.matrix())).
+		Transform(m.Transpose())
+}
+
+// Inverse returns the Helmert transformation that reverses h.
+//
+// The inverse is found by reversing the signs of the parameters, which is the
+// usual approximation for published parameter sets. For typical datum
+// transformations, the round trip error is around a centimeter. Use
+// InverseTransform when an exact inverse is required.
+func (h Helmert) Inverse() Helmert {
+	return Helmert{-h.TX, -h.TY, -h.TZ, -h.RX, -h.RY, -h.RZ, -h.Scale}
+}
+
+// InverseTransform returns the ECEF position vector v transformed by the exact
+// inverse of h, such that h.InverseTransform(h.Transform(v, f), f) returns v.
+// The coordinate frame f specifies the axes in which v, and the returned
+// vector, are decomposed.
+func (h Helmert) InverseTransform(v Vector, f Matrix) Vector {
+	// m changes the axes from f to ZAxisNorth
+	m := ZAxisNorth.Transpose().Multiply(f)
+
+	// the linearized rotation matrix is invertible for any realistic rotation
+	inv, _ := h.matrix().Inverse()
+
+	return v.Transform(m).
+		Sub(h.translation()).
+		Transform(inv).
+		Transform(m.Transpose())
+}
+
+// Then returns the Helmert transformation that applies h followed by g.
+//
+// The parameters are summed, which is accurate to first order in the
+// rotations and scale changes. As with Inverse, the error for typical datum
+// transformations is around a centimeter.
+func (h Helmert) Then(g Helmert) Helmert {
+	return Helmert{
+		h.TX + g.TX,
+		h.TY + g.TY,
+		h.TZ + g.TZ,
+		h.RX + g.RX,
+		h.RY + g.RY,
+		h.RZ + g.RZ,
+		h.Scale + g.Scale,
+	}
+}
+
+// TransformPosition returns the position p on the ellipsoid from, transformed
+// by h to a position on the ellipsoid to. The coordinate frame f specifies the
+// axes in which the n-vectors are decomposed.
+func (h Helmert) TransformPosition(
+	p Position,
+	from, to Ellipsoid,
+	f Matrix,
+) Position {
+	return FromECEF(h.Transform(ToECEF(p, from, f), f), to, f)
+}
+
+// translation returns the translation of h, decomposed with the axes selected
+// by ZAxisNorth.
+func (h Helmert) translation() Vector {
+	return Vector{h.TX, h.TY, h.TZ}
+}
+
+// matrix returns the linearized rotation matrix of h, including the scale
+// change, for vectors decomposed with the axes selected by ZAxisNorth.
+func (h Helmert) matrix() Matrix {
+	s := 1 + h.Scale
+
+	return Matrix{
+		s, -s * h.RZ, s * h.RY,
+		s * h.RZ, s, -s * h.RX,
+		-s * h.RY, s * h.RX, s,
+	}
+}
+
+// helmertFromPublished returns a Helmert transformation from parameters in the
+// units that are usually published: translations in meters, rotations in
+// arcseconds, and the scale change in parts per million.
+func helmertFromPublished(tx, ty, tz, rx, ry, rz, ppm float64) Helmert {
+	return Helmert{
+		tx, ty, tz,
+		Radians(rx / 3600), Radians(ry / 3600), Radians(rz / 3600),
+		ppm * 1e-6,
+	}
+}
@@ -0,0 +1,113 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Helmert(t *testing.T) {
+	// WGS 72 to WGS 84, from EPSG Guidance Note 7-2
+	h := Helmert{
+		TZ:    4.5,
+		RZ:    Radians(0.554 / 3600),
+		Scale: 0.219e-6,
+	}
+
+	t.Run("it matches the EPSG position vector example", func(t *testing.T) {
+		v := Vector{X: 3657660.66, Y: 255768.55, Z: 5201382.11}
+		want := Vector{X: 3657660.78, Y: 255778.43, Z: 5201387.75}
+
+		got := h.Transform(v, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToVector(got, want, 1e-2); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it is independent of the coordinate frame", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.EcefVector(WGS84).Draw(t, "vector")
+			f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+			// vf is v decomposed in f instead of ZAxisNorth
+			m := f.Transpose().Multiply(ZAxisNorth)
+			vf := v.Transform(m)
+
+			got := h.Transform(vf, f)
+			want := h.Transform(v, ZAxisNorth).Transform(m)
+
+			if eq, ineq := equality.EqualToVector(got, want, 1e-7); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it reverses exactly with InverseTransform", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.EcefVector(WGS84).Draw(t, "vector")
+			f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+			got := h.InverseTransform(h.Transform(v, f), f)
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-7); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it approximately reverses with Inverse", func(t *testing.T) {
+		datums := map[string]Datum{
+			"ED50":    DatumED50,
+			"OSGB36":  DatumOSGB36,
+			"Potsdam": DatumPotsdam,
+		}
+
+		for name, d := range datums {
+			t.Run(name, func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					v := rapidgen.EcefVector(d.Ellipsoid).Draw(t, "vector")
+
+					got := d.ToWGS84.Inverse().Transform(d.ToWGS84.Transform(v, ZAxisNorth), ZAxisNorth)
+
+					if eq, ineq := equality.EqualToVector(got, v, 0.05); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+				})
+			})
+		}
+	})
+
+	t.Run("it composes transformations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.EcefVector(WGS84).Draw(t, "vector")
+			g := DatumOSGB36.ToWGS84
+
+			got := h.Then(g).Transform(v, ZAxisNorth)
+			want := g.Transform(h.Transform(v, ZAxisNorth), ZAxisNorth)
+
+			if eq, ineq := equality.EqualToVector(got, want, 0.05); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it transforms positions between ellipsoids", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "vector"),
+				Depth:  rapid.Float64Range(-1e4, 1e5).Draw(t, "depth"),
+			}
+
+			got := ToECEF(h.TransformPosition(p, WGS72, WGS84, ZAxisNorth), WGS84, ZAxisNorth)
+			want := h.Transform(ToECEF(p, WGS72, ZAxisNorth), ZAxisNorth)
+
+			if eq, ineq := equality.EqualToVector(got, want, 1e-6); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
@@ -97,6 +97,30 @@ func EqualToMatrix(
 	return len(ineq) == 0, ineq
 }
 
+// EqualToQuaternion returns a boolean indicating whether two quaternions are
+// equal within a tolerance, and the inequality for each component.
+func EqualToQuaternion(
+	a, b nvector.Quaternion,
+	tol float64,
+) (bool, map[string]Float64Inequality) {
+	ineq := make(map[string]Float64Inequality, 4)
+
+	if eq, i := EqualToFloat64(a.W, b.W, tol); !eq {
+		ineq["W"] = i
+	}
+	if eq, i := EqualToFloat64(a.X, b.X, tol); !eq {
+		ineq["X"] = i
+	}
+	if eq, i := EqualToFloat64(a.Y, b.Y, tol); !eq {
+		ineq["Y"] = i
+	}
+	if eq, i := EqualToFloat64(a.Z, b.Z, tol); !eq {
+		ineq["Z"] = i
+	}
+
+	return len(ineq) == 0, ineq
+}
+
 // EqualToRadians returns a boolean indicating whether two angles are equal
 // within a tolerance, and the inequality.
 func EqualToRadians(a, b, tol float64) (bool, Float64Inequality) {
@@ -19,11 +19,12 @@ func EcefVector(e nvector.Ellipsoid) *rapid.Generator[nvector.Vector] {
 	)
 }
 
-// Ellipsoid creates a rapid generator for ellipsoids.
+// Ellipsoid creates a rapid generator for the ellipsoids in the catalogue.
 func Ellipsoid() *rapid.Generator[nvector.Ellipsoid] {
-	return rapid.SampledFrom([]nvector.Ellipsoid{
-		nvector.WGS84,
-		nvector.GRS80,
-		nvector.WGS72,
-	})
+	var es []nvector.Ellipsoid
+	for _, e := range nvector.Ellipsoids() {
+		es = append(es, e.Ellipsoid)
+	}
+
+	return rapid.SampledFrom(es)
 }
@@ -11,7 +11,7 @@ import (
 func RotationMatrix() *rapid.Generator[nvector.Matrix] {
 	return rapid.Custom(func(t *rapid.T) nvector.Matrix {
 		// based on https://github.com/rawify/Quaternion.js/blob/c3834673b502e64e1866dbbf13568c0be93e52cc/q.js#L791
-		q := quaternion().Draw(t, "quaternion")
+		q := Quaternion().Draw(t, "quaternion")
 		w, x, y, z := q.W, q.X, q.Y, q.Z
 
 		wx := w * x
@@ -32,12 +32,10 @@ func RotationMatrix() *rapid.Generator[nvector.Matrix] {
 	})
 }
 
-type quat struct {
-	W, X, Y, Z float64
-}
-
-func quaternion() *rapid.Generator[quat] {
-	return rapid.Custom(func(t *rapid.T) quat {
+// Quaternion creates a rapid generator for unit quaternions representing
+// uniformly distributed rotations.
+func Quaternion() *rapid.Generator[nvector.Quaternion] {
+	return rapid.Custom(func(t *rapid.T) nvector.Quaternion {
 		// based on https://github.com/mrdoob/three.js/blob/a2e9ee8204b67f9dca79f48cf620a34a05aa8126/src/math/Quaternion.js#L592
 		// Ken Shoemake
 		// Uniform random rotations
@@ -54,6 +52,6 @@ func quaternion() *rapid.Generator[quat] {
 		z := r2 * math.Sin(theta2)
 		w := r2 * math.Cos(theta2)
 
-		return quat{w, x, y, z}
+		return nvector.Quaternion{W: w, X: x, Y: y, Z: z}
 	})
 }
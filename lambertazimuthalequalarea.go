@@ -0,0 +1,195 @@
+package nvector
+
+import (
+	"errors"
+	"math"
+)
+
+// LambertAzimuthalEqualArea is a Lambert azimuthal equal-area projection
+// centered on a position.
+//
+// Areas are preserved everywhere, and the azimuth from the center to each
+// position is preserved on a sphere (an ellipsoid with zero flattening). On
+// other ellipsoids, positions are first mapped to the sphere with the same
+// surface area using their authalic latitude (EPSG method 9820).
+//
+// The projected y-axis points north from the center. If the center is at a
+// pole, north is the direction of the x-axis of the N frame returned by
+// ToRotationMatrix.
+//
+// See: EPSG Guidance Note 7-2, Section 3.10.2
+type LambertAzimuthalEqualArea struct {
+	// Ellipsoid is the reference ellipsoid.
+	Ellipsoid Ellipsoid
+	// Center is the n-vector at the center of the projection.
+	Center Vector
+	// FalseEasting and FalseNorthing are the projected coordinates of the
+	// center, in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the n-vector is antipodal to the center, which
+// projects to a circle rather than a point.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p LambertAzimuthalEqualArea) Forward(
+	v Vector,
+	f Matrix,
+) (ProjectedCoordinates, error) {
+	center, r, d := p.authalic(f)
+	n := authalicVector(v, p.Ellipsoid, f).
+		Transform(ToRotationMatrix(center, f).Transpose())
+	if 1-n.Z <= 0 {
+		return ProjectedCoordinates{}, errors.New(
+			"n-vector is antipodal to the center of the projection",
+		)
+	}
+
+	k := r * math.Sqrt(2/(1-n.Z))
+
+	return ProjectedCoordinates{
+		p.FalseEasting + d*k*n.Y,
+		p.FalseNorthing + k*n.X/d,
+	}, nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// An error is returned if the coordinates are outside the circle that contains
+// all projected positions.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p LambertAzimuthalEqualArea) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	center, r, d := p.authalic(f)
+	x := (c.Easting - p.FalseEasting) / (d * r)
+	y := (c.Northing - p.FalseNorthing) * d / r
+
+	rhoSq := x*x + y*y
+	if rhoSq > 4 {
+		return Vector{}, errors.New(
+			"projected coordinates are outside the projection",
+		)
+	}
+
+	// cos(c/2), where c is the angle from the center on the authalic sphere
+	m := math.Sqrt(1 - rhoSq/4)
+	u := Vector{y * m, x * m, rhoSq/2 - 1}.
+		Transform(ToRotationMatrix(center, f))
+
+	return geodeticVector(u, p.Ellipsoid, f), nil
+}
+
+// Distortion returns the scale factors and meridian convergence of the
+// projection at an n-vector.
+//
+// An error is returned if the n-vector is antipodal to the center.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p LambertAzimuthalEqualArea) Distortion(
+	v Vector,
+	f Matrix,
+) (Distortion, error) {
+	center, _, d := p.authalic(f)
+	u := authalicVector(v, p.Ellipsoid, f)
+	n := u.Transform(ToRotationMatrix(center, f).Transpose())
+	if 1-n.Z <= 0 {
+		return Distortion{}, errors.New(
+			"n-vector is antipodal to the center of the projection",
+		)
+	}
+
+	// The scale factor of the authalic mapping along the parallel. Its scale
+	// factor along the meridian is the reciprocal, preserving area.
+	k := 1.0
+	if w := v.Transform(f); p.Ellipsoid.Flattening != 0 && (w.Y != 0 || w.Z != 0) {
+		lat := math.Atan2(w.X, math.Hypot(w.Y, w.Z))
+		k = authalicParallelScale(lat, p.Ellipsoid)
+	}
+
+	north := ProjectedCoordinates{0, 1}
+	east := ProjectedCoordinates{1, 0}
+	if n.X != 0 || n.Y != 0 {
+		radial := math.Sqrt((1 - n.Z) / 2)
+		north, east = azimuthalJacobian(
+			radial,
+			1/radial,
+			math.Atan2(n.Y, n.X),
+			awayFrom(center, u, f),
+		)
+	}
+
+	return jacobianDistortion(
+		ProjectedCoordinates{north.Easting * d / k, north.Northing / (d * k)},
+		ProjectedCoordinates{east.Easting * d * k, east.Northing * k / d},
+	), nil
+}
+
+// authalic returns the center of the projection on the authalic sphere, the
+// radius of the authalic sphere, and the factor by which projected eastings
+// are stretched, and northings shrunk, so that scale is true in all directions
+// at the center.
+func (p LambertAzimuthalEqualArea) authalic(f Matrix) (Vector, float64, float64) {
+	e := p.Ellipsoid
+	center := authalicVector(p.Center, e, f)
+	r := e.AuthalicRadius()
+
+	w := p.Center.Transform(f)
+	cosLat := math.Hypot(w.Y, w.Z)
+
+	// The factor approaches 1 at the poles, where it can't be computed directly.
+	// Within 1e-8 radians of a pole, it differs from 1 by less than 1e-16.
+	if e.Flattening == 0 || cosLat < 1e-8 {
+		return center, r, 1
+	}
+
+	lat := math.Atan2(w.X, cosLat)
+
+	return center, r, 1 / authalicParallelScale(lat, e)
+}
+
+// authalicVector returns the n-vector with the same longitude as an n-vector,
+// and a geodetic latitude equal to its authalic latitude.
+func authalicVector(v Vector, e Ellipsoid, f Matrix) Vector {
+	return replaceLatitude(v, f, func(lat float64) float64 {
+		return authalicLatitude(lat, e)
+	})
+}
+
+// geodeticVector is the inverse of authalicVector.
+func geodeticVector(v Vector, e Ellipsoid, f Matrix) Vector {
+	return replaceLatitude(v, f, func(lat float64) float64 {
+		return authalicLatitudeInverse(lat, e)
+	})
+}
+
+// replaceLatitude returns the n-vector with the same longitude as an n-vector,
+// and a geodetic latitude that is a function of its geodetic latitude.
+// N-vectors at the poles are returned unchanged.
+func replaceLatitude(v Vector, f Matrix, fn func(float64) float64) Vector {
+	w := v.Transform(f)
+	r := math.Hypot(w.Y, w.Z)
+	if r == 0 {
+		return v
+	}
+
+	sinLat, cosLat := math.Sincos(fn(math.Atan2(w.X, r)))
+
+	return Vector{sinLat, w.Y / r * cosLat, w.Z / r * cosLat}.
+		Transform(f.Transpose())
+}
+
+// authalicParallelScale returns the ratio of the radius of the parallel of
+// authalic latitude on the authalic sphere to the radius of the parallel of
+// geodetic latitude on the ellipsoid.
+func authalicParallelScale(lat float64, e Ellipsoid) float64 {
+	e2 := e.EccentricitySquared()
+	sinLat, cosLat := math.Sincos(lat)
+	nu := e.SemiMajorAxis / math.Sqrt(1-e2*sinLat*sinLat)
+
+	return e.AuthalicRadius() * math.Cos(authalicLatitude(lat, e)) / (nu * cosLat)
+}
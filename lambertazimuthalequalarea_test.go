@@ -0,0 +1,269 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+// etrs89LAEA is the ETRS89 / LAEA Europe projection.
+//
+// See: EPSG Guidance Note 7-2, Section 3.10.2
+var etrs89LAEA = LambertAzimuthalEqualArea{
+	Ellipsoid: GRS80,
+	Center: FromGeodeticCoordinates(
+		GeodeticCoordinates{Radians(52), Radians(10)},
+		ZAxisNorth,
+	),
+	FalseEasting:  4321000,
+	FalseNorthing: 3210000,
+}
+
+func Test_LambertAzimuthalEqualArea_Forward(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(50), Radians(5)},
+			ZAxisNorth,
+		)
+
+		got, err := etrs89LAEA.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.Easting, 3962799.45, 1e-2); !eq {
+			equality.ReportInequality(t, "Easting", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Northing, 2999718.85, 1e-2); !eq {
+			equality.ReportInequality(t, "Northing", ineq)
+		}
+	})
+
+	t.Run("it matches the polar aspect", func(t *testing.T) {
+		// EPSG Guidance Note 7-2, Section 3.10.2, for the north polar aspect:
+		//   rho = a * sqrt(qP - q)
+		//   E = FE + rho * sin(lon - lon0)
+		//   N = FN - rho * cos(lon - lon0)
+		e := WGS84
+		ecc := math.Sqrt(e.Flattening * (2 - e.Flattening))
+		q := func(lat float64) float64 {
+			s := math.Sin(lat)
+			return (1 - ecc*ecc) * (s/(1-ecc*ecc*s*s) + math.Atanh(ecc*s)/ecc)
+		}
+
+		p := LambertAzimuthalEqualArea{
+			Ellipsoid: e,
+			Center: FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(90), Radians(10)},
+				ZAxisNorth,
+			),
+		}
+		lat, lon := Radians(70), Radians(40)
+		rho := e.SemiMajorAxis * math.Sqrt(q(math.Pi/2)-q(lat))
+
+		got, err := p.Forward(
+			FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth),
+			ZAxisNorth,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.Easting, rho*math.Sin(Radians(30)), 1e-6); !eq {
+			equality.ReportInequality(t, "Easting", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Northing, -rho*math.Cos(Radians(30)), 1e-6); !eq {
+			equality.ReportInequality(t, "Northing", ineq)
+		}
+	})
+
+	t.Run("it preserves area", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			p := LambertAzimuthalEqualArea{
+				Ellipsoid: e,
+				Center:    rapidgen.UnitVector().Draw(t, "center"),
+			}
+			lat := Radians(rapid.Float64Range(-80, 80).Draw(t, "latitude"))
+			lon := Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude"))
+			if FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth).
+				Dot(p.Center) < -0.9 {
+				// skip n-vectors near the antipode, where the projection is
+				// stretched too much to differentiate numerically
+				t.Skip()
+			}
+
+			forward := func(lat, lon float64) ProjectedCoordinates {
+				c, err := p.Forward(
+					FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth),
+					ZAxisNorth,
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return c
+			}
+
+			const step = 1e-6
+			n0, n1 := forward(lat-step, lon), forward(lat+step, lon)
+			e0, e1 := forward(lat, lon-step), forward(lat, lon+step)
+			projected := ((n1.Easting-n0.Easting)*(e1.Northing-e0.Northing) -
+				(n1.Northing-n0.Northing)*(e1.Easting-e0.Easting)) / (4 * step * step)
+
+			e2 := e.Flattening * (2 - e.Flattening)
+			w := math.Sqrt(1 - e2*math.Pow(math.Sin(lat), 2))
+			m := e.SemiMajorAxis * (1 - e2) / (w * w * w)
+			nu := e.SemiMajorAxis / w
+
+			if eq, ineq := equality.EqualToFloat64(
+				math.Abs(projected)/(m*nu*math.Cos(lat)),
+				1,
+				1e-6,
+			); !eq {
+				equality.ReportInequality(t, "area scale", ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error at the antipode of the center", func(t *testing.T) {
+		p := LambertAzimuthalEqualArea{
+			Ellipsoid: Sphere(6371e3),
+			Center:    Vector{X: 1, Y: 0, Z: 0},
+		}
+		v := Vector{X: -1, Y: 0, Z: 0}
+
+		if _, err := p.Forward(v, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+
+		p.Ellipsoid = WGS84
+		if _, err := p.Forward(v, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_LambertAzimuthalEqualArea_Inverse(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v, err := etrs89LAEA.Inverse(
+			ProjectedCoordinates{3962799.45, 2999718.85},
+			ZAxisNorth,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ToGeodeticCoordinates(v, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToRadians(got.Latitude, Radians(50), 5e-9); !eq {
+			equality.ReportInequality(t, "Latitude", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Longitude, Radians(5), 5e-9); !eq {
+			equality.ReportInequality(t, "Longitude", ineq)
+		}
+	})
+
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapid.SampledFrom([]Ellipsoid{Sphere(6371e3), WGS84, GRS80}).
+				Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			p := LambertAzimuthalEqualArea{
+				Ellipsoid:     e,
+				Center:        rapidgen.UnitVector().Draw(t, "center"),
+				FalseEasting:  rapid.Float64Range(-1e6, 1e6).Draw(t, "falseEasting"),
+				FalseNorthing: rapid.Float64Range(-1e6, 1e6).Draw(t, "falseNorthing"),
+			}
+			v := rapidgen.UnitVector().Draw(t, "v")
+			if v.Dot(p.Center) < -0.99 {
+				// skip n-vectors near the antipode, which project to a narrow ring
+				// where small errors in the projected coordinates are magnified
+				t.Skip()
+			}
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-11); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error outside the projection", func(t *testing.T) {
+		p := LambertAzimuthalEqualArea{
+			Ellipsoid: Sphere(6371e3),
+			Center:    FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+
+		if _, err := p.Inverse(ProjectedCoordinates{2.1 * 6371e3, 0}, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_LambertAzimuthalEqualArea_Distortion(t *testing.T) {
+	t.Run("it has reciprocal scale factors on a sphere", func(t *testing.T) {
+		p := LambertAzimuthalEqualArea{
+			Ellipsoid: Sphere(6371e3),
+			Center:    FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(90)},
+			ZAxisNorth,
+		)
+
+		got, err := p.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, math.Sqrt2, 1e-15); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 1/math.Sqrt2, 1e-15); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 1e-15); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it has no distortion at the center", func(t *testing.T) {
+		got, err := etrs89LAEA.Distortion(etrs89LAEA.Center, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, 1, 1e-12); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 1, 1e-12); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 1e-15); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it returns an error at the antipode of the center", func(t *testing.T) {
+		p := LambertAzimuthalEqualArea{
+			Ellipsoid: WGS84,
+			Center:    Vector{X: 1, Y: 0, Z: 0},
+		}
+
+		if _, err := p.Distortion(Vector{X: -1, Y: 0, Z: 0}, XAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package nvector
+
+import (
+	"fmt"
+	"math"
+)
+
+// LambertConformalConic is a Lambert conformal conic projection.
+//
+// For the variant with two standard parallels (EPSG method 9802), set Origin to
+// the false origin, and ScaleFactor to 1. For the variant with one standard
+// parallel (EPSG method 9801), set both standard parallels to the latitude of
+// the natural origin, and ScaleFactor to the scale factor at the natural
+// origin.
+//
+// See: EPSG Guidance Note 7-2, Section 3.2.1
+type LambertConformalConic struct {
+	// Ellipsoid is the reference ellipsoid.
+	Ellipsoid Ellipsoid
+	// Origin is the origin of the projection. Its longitude is the central
+	// meridian.
+	Origin GeodeticCoordinates
+	// StandardParallel1 and StandardParallel2 are the latitudes in radians at
+	// which the cone intersects the ellipsoid. They may be equal, in which case
+	// the cone is tangent to the ellipsoid.
+	StandardParallel1, StandardParallel2 float64
+	// ScaleFactor is the scale factor on the standard parallels.
+	ScaleFactor float64
+	// FalseEasting and FalseNorthing are the projected coordinates of the
+	// origin, in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the standard parallels don't define a cone, or if
+// the n-vector is at the pole opposite the apex of the cone, which projects to
+// infinity.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p LambertConformalConic) Forward(
+	v Vector,
+	f Matrix,
+) (ProjectedCoordinates, error) {
+	n, cf, err := p.cone()
+	if err != nil {
+		return ProjectedCoordinates{}, err
+	}
+
+	r, theta, _, err := p.polar(v, f, n, cf)
+	if err != nil {
+		return ProjectedCoordinates{}, err
+	}
+
+	return ProjectedCoordinates{
+		p.FalseEasting + r*math.Sin(theta),
+		p.FalseNorthing + p.radius(p.Origin.Latitude, n, cf) - r*math.Cos(theta),
+	}, nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// An error is returned if the standard parallels don't define a cone.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p LambertConformalConic) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	n, cf, err := p.cone()
+	if err != nil {
+		return Vector{}, err
+	}
+
+	x := c.Easting - p.FalseEasting
+	y := p.radius(p.Origin.Latitude, n, cf) - (c.Northing - p.FalseNorthing)
+	if n < 0 {
+		x, y = -x, -y
+	}
+
+	r := math.Copysign(math.Hypot(x, y), n)
+	theta := math.Atan2(x, y)
+	t := math.Pow(r/(p.Ellipsoid.SemiMajorAxis*cf*p.ScaleFactor), 1/n)
+	lat := conformalTInverse(t, p.Ellipsoid)
+
+	return FromGeodeticCoordinates(
+		GeodeticCoordinates{lat, p.Origin.Longitude + theta/n},
+		f,
+	), nil
+}
+
+// Distortion returns the point scale factor and meridian convergence of the
+// projection at an n-vector.
+//
+// An error is returned if the standard parallels don't define a cone, or if the
+// n-vector is at either pole. The projection is not conformal at the apex of
+// the cone, and the pole opposite the apex projects to infinity.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p LambertConformalConic) Distortion(v Vector, f Matrix) (Distortion, error) {
+	n, cf, err := p.cone()
+	if err != nil {
+		return Distortion{}, err
+	}
+
+	r, theta, lat, err := p.polar(v, f, n, cf)
+	if err != nil {
+		return Distortion{}, err
+	}
+	if math.Abs(lat) == math.Pi/2 {
+		return Distortion{}, fmt.Errorf(
+			"latitude %v° is at the apex of the cone",
+			Degrees(lat),
+		)
+	}
+
+	k := r * n / (p.Ellipsoid.SemiMajorAxis * parallelM(lat, p.Ellipsoid))
+
+	return Distortion{k, k, theta}, nil
+}
+
+// polar returns the polar coordinates of an n-vector on the projection, and its
+// latitude, given the constants of the cone.
+func (p LambertConformalConic) polar(
+	v Vector,
+	f Matrix,
+	n, cf float64,
+) (r, theta, lat float64, err error) {
+	c := ToGeodeticCoordinates(v, f)
+
+	if math.Abs(c.Latitude) == math.Pi/2 &&
+		math.Signbit(c.Latitude) != math.Signbit(n) {
+		return 0, 0, 0, fmt.Errorf(
+			"latitude %v° is at the pole opposite the apex of the cone",
+			Degrees(c.Latitude),
+		)
+	}
+
+	lon := math.Remainder(c.Longitude-p.Origin.Longitude, 2*math.Pi)
+
+	return p.radius(c.Latitude, n, cf), n * lon, c.Latitude, nil
+}
+
+// radius returns the distance in meters on the projection from the apex of the
+// cone to a latitude, given the constants of the cone.
+func (p LambertConformalConic) radius(lat, n, cf float64) float64 {
+	return p.Ellipsoid.SemiMajorAxis * cf * math.Pow(conformalT(lat, p.Ellipsoid), n) *
+		p.ScaleFactor
+}
+
+// cone returns the cone constant n, and the constant F of the projection.
+//
+// An error is returned if the standard parallels don't define a cone, e.g.
+// when they're symmetric about the equator, which gives a cone constant of 0.
+func (p LambertConformalConic) cone() (n, cf float64, err error) {
+	m1 := parallelM(p.StandardParallel1, p.Ellipsoid)
+	t1 := conformalT(p.StandardParallel1, p.Ellipsoid)
+
+	if p.StandardParallel1 == p.StandardParallel2 {
+		n = math.Sin(p.StandardParallel1)
+	} else {
+		m2 := parallelM(p.StandardParallel2, p.Ellipsoid)
+		t2 := conformalT(p.StandardParallel2, p.Ellipsoid)
+		n = (math.Log(m1) - math.Log(m2)) / (math.Log(t1) - math.Log(t2))
+	}
+
+	cf = m1 / (n * math.Pow(t1, n))
+	if n == 0 || math.IsNaN(n) || math.IsInf(n, 0) ||
+		math.IsNaN(cf) || math.IsInf(cf, 0) {
+		return 0, 0, fmt.Errorf(
+			"standard parallels %v° and %v° don't define a cone",
+			Degrees(p.StandardParallel1),
+			Degrees(p.StandardParallel2),
+		)
+	}
+
+	return n, cf, nil
+}
+
+// parallelM returns the ratio of the radius of a parallel to the semi-major axis
+// of the ellipsoid.
+//
+// See: Equation (14-15) in Snyder (1987).
+func parallelM(lat float64, e Ellipsoid) float64 {
+	e2 := e.EccentricitySquared()
+	s, c := math.Sincos(lat)
+
+	return c / math.Sqrt(1-e2*s*s)
+}
+
+// conformalT returns the tangent of half the conformal colatitude of a
+// latitude.
+//
+// See: Equation (15-9) in Snyder (1987).
+func conformalT(lat float64, e Ellipsoid) float64 {
+	ecc := e.Eccentricity()
+	es := ecc * math.Sin(lat)
+
+	return math.Tan(math.Pi/4-lat/2) / math.Pow((1-es)/(1+es), ecc/2)
+}
+
+// conformalTInverse is the inverse of conformalT.
+//
+// See: Equation (7-9) in Snyder (1987).
+func conformalTInverse(t float64, e Ellipsoid) float64 {
+	ecc := e.Eccentricity()
+
+	// Solve for the latitude by fixed-point iteration
+	lat := math.Pi/2 - 2*math.Atan(t)
+	for range 20 {
+		es := ecc * math.Sin(lat)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-es)/(1+es), ecc/2))
+		done := math.Abs(next-lat) <= 1e-15
+		lat = next
+
+		if done {
+			break
+		}
+	}
+
+	return lat
+}
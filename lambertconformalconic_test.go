@@ -0,0 +1,281 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+// usSurveyFoot is the length of a US survey foot in meters.
+const usSurveyFoot = 1200.0 / 3937
+
+// clarke1866 is the Clarke 1866 ellipsoid.
+var clarke1866 = Ellipsoid{6378206.4, 6378206.4 * (1 - 1/294.9786982), 1 / 294.9786982}
+
+// texasSouthCentral is the NAD27 Texas South Central zone, which uses the
+// variant with two standard parallels.
+//
+// See: EPSG Guidance Note 7-2, Section 3.2.1.1
+var texasSouthCentral = LambertConformalConic{
+	Ellipsoid:         clarke1866,
+	Origin:            GeodeticCoordinates{Radians(27 + 50.0/60), Radians(-99)},
+	StandardParallel1: Radians(28 + 23.0/60),
+	StandardParallel2: Radians(30 + 17.0/60),
+	ScaleFactor:       1,
+	FalseEasting:      2000000 * usSurveyFoot,
+	FalseNorthing:     0,
+}
+
+// jamaicaGrid is the JAD69 Jamaica National Grid, which uses the variant with
+// one standard parallel.
+//
+// See: EPSG Guidance Note 7-2, Section 3.2.1.1
+var jamaicaGrid = LambertConformalConic{
+	Ellipsoid:         clarke1866,
+	Origin:            GeodeticCoordinates{Radians(18), Radians(-77)},
+	StandardParallel1: Radians(18),
+	StandardParallel2: Radians(18),
+	ScaleFactor:       1,
+	FalseEasting:      250000,
+	FalseNorthing:     150000,
+}
+
+// degenerateCones are projections whose standard parallels don't define a
+// cone, because the cone constant is 0.
+var degenerateCones = map[string]LambertConformalConic{
+	"symmetric standard parallels": {
+		Ellipsoid:         WGS84,
+		StandardParallel1: Radians(30),
+		StandardParallel2: Radians(-30),
+		ScaleFactor:       1,
+	},
+	"equatorial standard parallel": {
+		Ellipsoid:   WGS84,
+		ScaleFactor: 1,
+	},
+}
+
+func Test_LambertConformalConic_Forward(t *testing.T) {
+	tests := map[string]struct {
+		p                 LambertConformalConic
+		lat, lon          float64
+		easting, northing float64
+		unit              float64
+	}{
+		"two standard parallels": {
+			p:        texasSouthCentral,
+			lat:      28 + 30.0/60,
+			lon:      -96,
+			easting:  2963503.91,
+			northing: 254759.80,
+			unit:     usSurveyFoot,
+		},
+		"one standard parallel": {
+			p:        jamaicaGrid,
+			lat:      17 + 55.0/60 + 55.80/3600,
+			lon:      -76 - 56.0/60 - 37.26/3600,
+			easting:  255966.58,
+			northing: 142493.51,
+			unit:     1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run("it matches the EPSG worked example with "+name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			got, err := tt.p.Forward(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToFloat64(got.Easting/tt.unit, tt.easting, 1e-2); !eq {
+				equality.ReportInequality(t, "Easting", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Northing/tt.unit, tt.northing, 1e-2); !eq {
+				equality.ReportInequality(t, "Northing", ineq)
+			}
+		})
+	}
+
+	t.Run("it returns an error at the pole opposite the apex", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(-90), 0},
+			ZAxisNorth,
+		)
+
+		if _, err := texasSouthCentral.Forward(v, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("it returns an error when the standard parallels don't define a cone", func(t *testing.T) {
+		v := FromGeodeticCoordinates(GeodeticCoordinates{Radians(10), 0}, ZAxisNorth)
+
+		for name, p := range degenerateCones {
+			if _, err := p.Forward(v, ZAxisNorth); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
+
+func Test_LambertConformalConic_Inverse(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v, err := texasSouthCentral.Inverse(
+			ProjectedCoordinates{
+				2963503.91 * usSurveyFoot,
+				254759.80 * usSurveyFoot,
+			},
+			ZAxisNorth,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ToGeodeticCoordinates(v, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToRadians(got.Latitude, Radians(28.5), 5e-9); !eq {
+			equality.ReportInequality(t, "Latitude", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Longitude, Radians(-96), 5e-9); !eq {
+			equality.ReportInequality(t, "Longitude", ineq)
+		}
+	})
+
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			sign := rapid.SampledFrom([]float64{-1, 1}).Draw(t, "hemisphere")
+			p := LambertConformalConic{
+				Ellipsoid: rapidgen.Ellipsoid().Draw(t, "ellipsoid"),
+				Origin: GeodeticCoordinates{
+					Latitude:  sign * Radians(rapid.Float64Range(10, 80).Draw(t, "originLatitude")),
+					Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "originLongitude")),
+				},
+				StandardParallel1: sign * Radians(rapid.Float64Range(10, 80).Draw(t, "standardParallel1")),
+				StandardParallel2: sign * Radians(rapid.Float64Range(10, 80).Draw(t, "standardParallel2")),
+				ScaleFactor:       rapid.Float64Range(0.9, 1.1).Draw(t, "scaleFactor"),
+				FalseEasting:      rapid.Float64Range(-1e6, 1e6).Draw(t, "falseEasting"),
+				FalseNorthing:     rapid.Float64Range(-1e6, 1e6).Draw(t, "falseNorthing"),
+			}
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{
+					Latitude:  Radians(rapid.Float64Range(-80, 80).Draw(t, "latitude")),
+					Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude")),
+				},
+				f,
+			)
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error when the standard parallels don't define a cone", func(t *testing.T) {
+		for name, p := range degenerateCones {
+			if _, err := p.Inverse(ProjectedCoordinates{1000, 1000}, ZAxisNorth); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
+
+func Test_LambertConformalConic_Distortion(t *testing.T) {
+	t.Run("it has the scale factor on the standard parallels", func(t *testing.T) {
+		for _, lat := range []float64{
+			texasSouthCentral.StandardParallel1,
+			texasSouthCentral.StandardParallel2,
+		} {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{lat, Radians(-97)},
+				ZAxisNorth,
+			)
+
+			got, err := texasSouthCentral.Distortion(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToFloat64(got.MeridianScale, 1, 1e-12); !eq {
+				equality.ReportInequality(t, "MeridianScale", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 1, 1e-12); !eq {
+				equality.ReportInequality(t, "ParallelScale", ineq)
+			}
+		}
+	})
+
+	t.Run("it matches the spherical formulas", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := LambertConformalConic{
+				Ellipsoid:         Sphere(6371e3),
+				StandardParallel1: Radians(30),
+				StandardParallel2: Radians(60),
+				ScaleFactor:       1,
+			}
+			lat := Radians(rapid.Float64Range(-80, 89).Draw(t, "latitude"))
+			lon := Radians(rapid.Float64Range(-179, 179).Draw(t, "longitude"))
+			v := FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth)
+
+			got, err := p.Distortion(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Equations (15-3), (15-4) and (15-2a) in Snyder (1987)
+			t1 := math.Tan(math.Pi/4 + p.StandardParallel1/2)
+			t2 := math.Tan(math.Pi/4 + p.StandardParallel2/2)
+			n := math.Log(math.Cos(p.StandardParallel1)/math.Cos(p.StandardParallel2)) /
+				math.Log(t2/t1)
+			cf := math.Cos(p.StandardParallel1) * math.Pow(t1, n) / n
+			wantK := cf * n / (math.Cos(lat) * math.Pow(math.Tan(math.Pi/4+lat/2), n))
+
+			if eq, ineq := equality.EqualToFloat64(got.ParallelScale, wantK, 1e-12); !eq {
+				equality.ReportInequality(t, "ParallelScale", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(got.Convergence, n*lon, 1e-12); !eq {
+				equality.ReportInequality(t, "Convergence", ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error at the poles", func(t *testing.T) {
+		for _, lat := range []float64{-90, 90} {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(lat), 0},
+				ZAxisNorth,
+			)
+
+			if _, err := texasSouthCentral.Distortion(v, ZAxisNorth); err == nil {
+				t.Errorf("latitude %v: expected an error", lat)
+			}
+		}
+	})
+
+	t.Run("it returns an error when the standard parallels don't define a cone", func(t *testing.T) {
+		v := FromGeodeticCoordinates(GeodeticCoordinates{Radians(10), 0}, ZAxisNorth)
+
+		for name, p := range degenerateCones {
+			if _, err := p.Distortion(v, ZAxisNorth); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package nvector
+
+import (
+	"math"
+	"strconv"
+)
+
+// AuxiliaryLatitude is a kind of latitude that maps an ellipsoid to a sphere
+// while preserving some property, such as area or angles.
+//
+// See: https://arxiv.org/abs/2212.05818
+type AuxiliaryLatitude int
+
+const (
+	// GeodeticLatitude is the angle between the normal to the ellipsoid and
+	// the equatorial plane. It is the latitude of GeodeticCoordinates.
+	GeodeticLatitude AuxiliaryLatitude = iota
+	// GeocentricLatitude is the angle between the radius from the center of
+	// the ellipsoid and the equatorial plane.
+	GeocentricLatitude
+	// ParametricLatitude, also known as reduced latitude, is the latitude on
+	// the sphere with a radius equal to the semi-major axis, of the point with
+	// the same distance from the polar axis.
+	ParametricLatitude
+	// AuthalicLatitude is the latitude on the sphere with the same surface
+	// area as the ellipsoid that preserves area.
+	AuthalicLatitude
+	// ConformalLatitude is the latitude on a sphere that preserves angles.
+	ConformalLatitude
+	// RectifyingLatitude is the latitude on the sphere with the same meridian
+	// length as the ellipsoid that preserves distances along meridians.
+	RectifyingLatitude
+	// IsometricLatitude is the northing of the Mercator projection of the
+	// ellipsoid with a unit equatorial radius. Unlike the other latitudes, it
+	// is not an angle, and is infinite at the poles.
+	IsometricLatitude
+)
+
+// String returns the name of the latitude, e.g. "geodetic".
+func (l AuxiliaryLatitude) String() string {
+	switch l {
+	case GeodeticLatitude:
+		return "geodetic"
+	case GeocentricLatitude:
+		return "geocentric"
+	case ParametricLatitude:
+		return "parametric"
+	case AuthalicLatitude:
+		return "authalic"
+	case ConformalLatitude:
+		return "conformal"
+	case RectifyingLatitude:
+		return "rectifying"
+	case IsometricLatitude:
+		return "isometric"
+	}
+
+	return "AuxiliaryLatitude(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ConvertLatitude converts a latitude of one kind to another, on the ellipsoid
+// e. Latitudes are in radians, except for the isometric latitude, which is
+// dimensionless.
+//
+// Geocentric, parametric, conformal, and isometric latitudes are converted
+// exactly. Authalic and rectifying latitudes are converted using 6th-order
+// series in the third flattening, which are accurate to machine precision for
+// terrestrial ellipsoids.
+//
+// Unknown kinds of latitude are converted to NaN.
+//
+// See: https://arxiv.org/abs/2212.05818
+func ConvertLatitude(lat float64, from, to AuxiliaryLatitude, e Ellipsoid) float64 {
+	if from == to {
+		return lat
+	}
+
+	return fromGeodeticLatitude(toGeodeticLatitude(lat, from, e), to, e)
+}
+
+// fromGeodeticLatitude converts a geodetic latitude to another kind of
+// latitude.
+func fromGeodeticLatitude(lat float64, to AuxiliaryLatitude, e Ellipsoid) float64 {
+	switch to {
+	case GeodeticLatitude:
+		return lat
+	case GeocentricLatitude:
+		s, c := math.Sincos(lat)
+		r := 1 - e.Flattening
+
+		return math.Atan2(r*r*s, c)
+	case ParametricLatitude:
+		s, c := math.Sincos(lat)
+
+		return math.Atan2((1-e.Flattening)*s, c)
+	case AuthalicLatitude:
+		return authalicLatitude(lat, e)
+	case ConformalLatitude:
+		return math.Atan(conformalTau(math.Tan(lat), e))
+	case RectifyingLatitude:
+		return latitudeSeries(lat, toRectifyingCoefficients(thirdFlattening(e)))
+	case IsometricLatitude:
+		return math.Asinh(conformalTau(math.Tan(lat), e))
+	}
+
+	return math.NaN()
+}
+
+// toGeodeticLatitude converts a latitude of another kind to a geodetic
+// latitude.
+func toGeodeticLatitude(lat float64, from AuxiliaryLatitude, e Ellipsoid) float64 {
+	switch from {
+	case GeodeticLatitude:
+		return lat
+	case GeocentricLatitude:
+		s, c := math.Sincos(lat)
+		r := 1 - e.Flattening
+
+		return math.Atan2(s, r*r*c)
+	case ParametricLatitude:
+		s, c := math.Sincos(lat)
+
+		return math.Atan2(s, (1-e.Flattening)*c)
+	case AuthalicLatitude:
+		return authalicLatitudeInverse(lat, e)
+	case ConformalLatitude:
+		return math.Atan(conformalTauInverse(math.Tan(lat), e))
+	case RectifyingLatitude:
+		return latitudeSeries(lat, fromRectifyingCoefficients(thirdFlattening(e)))
+	case IsometricLatitude:
+		return math.Atan(conformalTauInverse(math.Sinh(lat), e))
+	}
+
+	return math.NaN()
+}
+
+// conformalTau returns the tangent of the conformal latitude of a geodetic
+// latitude, given the tangent of the geodetic latitude.
+//
+// See: Equation (7) in Karney (2011), https://arxiv.org/abs/1002.1417
+func conformalTau(tau float64, e Ellipsoid) float64 {
+	ecc := e.Eccentricity()
+	sigma := math.Sinh(ecc * math.Atanh(ecc*tau/math.Sqrt(1+tau*tau)))
+
+	return tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+}
+
+// conformalTauInverse is the inverse of conformalTau, solved using Newton's
+// method.
+//
+// See: Equations (19) to (21) in Karney (2011), https://arxiv.org/abs/1002.1417
+func conformalTauInverse(tauP float64, e Ellipsoid) float64 {
+	e2 := e.EccentricitySquared()
+
+	tau := tauP
+	for range 10 {
+		tauI := conformalTau(tau, e)
+		dTau := (tauP - tauI) / math.Sqrt(1+tauI*tauI) *
+			(1 + (1-e2)*tau*tau) / ((1 - e2) * math.Sqrt(1+tau*tau))
+		tau += dTau
+
+		if math.Abs(dTau) <= 1e-12*math.Max(1, math.Abs(tau)) {
+			break
+		}
+	}
+
+	return tau
+}
+
+// authalicLatitude returns the authalic latitude of a geodetic latitude, using
+// a 6th-order series in the third flattening.
+//
+// See: https://arxiv.org/abs/2212.05818
+func authalicLatitude(lat float64, e Ellipsoid) float64 {
+	return latitudeSeries(lat, toAuthalicCoefficients(thirdFlattening(e)))
+}
+
+// authalicLatitudeInverse returns the geodetic latitude of an authalic
+// latitude, using a 6th-order series in the third flattening.
+//
+// See: https://arxiv.org/abs/2212.05818
+func authalicLatitudeInverse(beta float64, e Ellipsoid) float64 {
+	return latitudeSeries(beta, fromAuthalicCoefficients(thirdFlattening(e)))
+}
+
+// thirdFlattening returns the third flattening of the ellipsoid.
+func thirdFlattening(e Ellipsoid) float64 {
+	return e.Flattening / (2 - e.Flattening)
+}
+
+// latitudeSeries returns lat + sum(c[j-1] * sin(2 * j * lat)).
+func latitudeSeries(lat float64, c [6]float64) float64 {
+	r := lat
+	for j := 1; j <= 6; j++ {
+		r += c[j-1] * math.Sin(float64(2*j)*lat)
+	}
+
+	return r
+}
+
+// toAuthalicCoefficients returns the coefficients of the series used to convert
+// geodetic latitude to authalic latitude.
+func toAuthalicCoefficients(n float64) [6]float64 {
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	return [6]float64{
+		-4*n/3 - 4*n2/45 + 88*n3/315 + 538*n4/4725 + 20824*n5/467775 - 44732*n6/2837835,
+		34*n2/45 + 8*n3/105 - 2482*n4/14175 - 37192*n5/467775 - 12467764*n6/212837625,
+		-1532*n3/2835 - 898*n4/14175 + 54968*n5/467775 + 100320856*n6/1915538625,
+		6007*n4/14175 + 24496*n5/467775 - 5884124*n6/70945875,
+		-23356*n5/66825 - 839792*n6/19348875,
+		570284222 * n6 / 1915538625,
+	}
+}
+
+// fromAuthalicCoefficients returns the coefficients of the series used to
+// convert authalic latitude to geodetic latitude.
+func fromAuthalicCoefficients(n float64) [6]float64 {
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	return [6]float64{
+		4*n/3 + 4*n2/45 - 16*n3/35 - 2582*n4/14175 + 60136*n5/467775 + 28112932*n6/212837625,
+		46*n2/45 + 152*n3/945 - 11966*n4/14175 - 21016*n5/51975 + 251310128*n6/638512875,
+		3044*n3/2835 + 3802*n4/14175 - 94388*n5/66825 - 8797648*n6/10945935,
+		6059*n4/4725 + 41072*n5/93555 - 1472637812*n6/638512875,
+		768272*n5/467775 + 455935736*n6/638512875,
+		4210684958 * n6 / 1915538625,
+	}
+}
+
+// toRectifyingCoefficients returns the coefficients of the series used to
+// convert geodetic latitude to rectifying latitude.
+//
+// See: https://arxiv.org/abs/2212.05818
+func toRectifyingCoefficients(n float64) [6]float64 {
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	return [6]float64{
+		-3*n/2 + 9*n3/16 - 3*n5/32,
+		15*n2/16 - 15*n4/32 + 135*n6/2048,
+		-35*n3/48 + 105*n5/256,
+		315*n4/512 - 189*n6/512,
+		-693 * n5 / 1280,
+		1001 * n6 / 2048,
+	}
+}
+
+// fromRectifyingCoefficients returns the coefficients of the series used to
+// convert rectifying latitude to geodetic latitude.
+//
+// See: https://arxiv.org/abs/2212.05818
+func fromRectifyingCoefficients(n float64) [6]float64 {
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	return [6]float64{
+		3*n/2 - 27*n3/32 + 269*n5/512,
+		21*n2/16 - 55*n4/32 + 6759*n6/4096,
+		151*n3/96 - 417*n5/128,
+		1097*n4/512 - 15543*n6/2560,
+		8011 * n5 / 2560,
+		293393 * n6 / 61440,
+	}
+}
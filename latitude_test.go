@@ -0,0 +1,228 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ConvertLatitude(t *testing.T) {
+	latitudes := []AuxiliaryLatitude{
+		GeodeticLatitude,
+		GeocentricLatitude,
+		ParametricLatitude,
+		AuthalicLatitude,
+		ConformalLatitude,
+		RectifyingLatitude,
+		IsometricLatitude,
+	}
+
+	// geodetic draws a geodetic latitude away from the poles, where the
+	// isometric latitude is infinite
+	geodetic := func(t *rapid.T) float64 {
+		return Radians(rapid.Float64Range(-89.9, 89.9).Draw(t, "latitude"))
+	}
+
+	t.Run("it round trips between all kinds of latitude", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			lat := geodetic(t)
+			from := rapid.SampledFrom(latitudes).Draw(t, "from")
+			to := rapid.SampledFrom(latitudes).Draw(t, "to")
+
+			in := ConvertLatitude(lat, GeodeticLatitude, from, e)
+			got := ConvertLatitude(ConvertLatitude(in, from, to, e), to, from, e)
+
+			if eq, ineq := equality.EqualToFloat64(got, in, 1e-12); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+		})
+	})
+
+	t.Run("it matches the geocentric latitude of ECEF positions", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			lat := geodetic(t)
+
+			v := FromGeodeticCoordinates(GeodeticCoordinates{Latitude: lat}, ZAxisNorth)
+			p := ToECEF(Position{Vector: v}, e, ZAxisNorth)
+			want := math.Atan2(p.Z, math.Hypot(p.X, p.Y))
+
+			got := ConvertLatitude(lat, GeodeticLatitude, GeocentricLatitude, e)
+
+			if eq, ineq := equality.EqualToRadians(got, want, 1e-14); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+		})
+	})
+
+	t.Run("it matches the parametric latitude of ECEF positions", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			lat := geodetic(t)
+
+			v := FromGeodeticCoordinates(GeodeticCoordinates{Latitude: lat}, ZAxisNorth)
+			p := ToECEF(Position{Vector: v}, e, ZAxisNorth)
+			b := e.SemiMajorAxis * (1 - e.Flattening)
+			want := math.Atan2(p.Z/b, math.Hypot(p.X, p.Y)/e.SemiMajorAxis)
+
+			got := ConvertLatitude(lat, GeodeticLatitude, ParametricLatitude, e)
+
+			if eq, ineq := equality.EqualToRadians(got, want, 1e-14); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+		})
+	})
+
+	t.Run("it matches the closed form of the authalic latitude", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			lat := geodetic(t)
+
+			// See: Equations (3-11) and (3-12) in Snyder (1987).
+			ecc := e.Eccentricity()
+			q := func(lat float64) float64 {
+				s := math.Sin(lat)
+
+				return (1 - ecc*ecc) * (s/(1-ecc*ecc*s*s) + math.Atanh(ecc*s)/ecc)
+			}
+			want := math.Asin(q(lat) / q(math.Pi/2))
+
+			got := ConvertLatitude(lat, GeodeticLatitude, AuthalicLatitude, e)
+
+			// the closed form loses precision near the poles, where the arcsine
+			// is ill-conditioned
+			if eq, ineq := equality.EqualToRadians(got, want, 1e-12); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+		})
+	})
+
+	t.Run("it matches the closed forms of the conformal and isometric latitudes", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			lat := geodetic(t)
+
+			// See: Equations (3-1) and (3-7) in Snyder (1987).
+			ecc := e.Eccentricity()
+			es := ecc * math.Sin(lat)
+			wantIsometric := math.Log(
+				math.Tan(math.Pi/4+lat/2) * math.Pow((1-es)/(1+es), ecc/2),
+			)
+			wantConformal := 2*math.Atan(math.Exp(wantIsometric)) - math.Pi/2
+
+			conformal := ConvertLatitude(lat, GeodeticLatitude, ConformalLatitude, e)
+			isometric := ConvertLatitude(lat, GeodeticLatitude, IsometricLatitude, e)
+
+			if eq, ineq := equality.EqualToRadians(conformal, wantConformal, 1e-13); !eq {
+				equality.ReportInequality(t, "Conformal", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(isometric, wantIsometric, 1e-12); !eq {
+				equality.ReportInequality(t, "Isometric", ineq)
+			}
+		})
+	})
+
+	t.Run("it matches the meridian distance for the rectifying latitude", func(t *testing.T) {
+		// meridian returns the distance along the meridian from the equator,
+		// integrated using Simpson's rule
+		meridian := func(lat float64, e Ellipsoid) float64 {
+			const steps = 1000
+			e2 := e.EccentricitySquared()
+			h := lat / steps
+
+			sum := 0.0
+			for i := 0; i <= steps; i++ {
+				s := math.Sin(float64(i) * h)
+				m := math.Pow(1-e2*s*s, -1.5)
+
+				switch {
+				case i == 0 || i == steps:
+					sum += m
+				case i%2 == 1:
+					sum += 4 * m
+				default:
+					sum += 2 * m
+				}
+			}
+
+			return e.SemiMajorAxis * (1 - e2) * sum * h / 3
+		}
+
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			lat := geodetic(t)
+
+			want := math.Pi / 2 * meridian(lat, e) / meridian(math.Pi/2, e)
+
+			got := ConvertLatitude(lat, GeodeticLatitude, RectifyingLatitude, e)
+
+			if eq, ineq := equality.EqualToRadians(got, want, 1e-14); !eq {
+				equality.ReportInequality(t, "Latitude", ineq)
+			}
+		})
+	})
+
+	t.Run("it preserves the equator and the poles", func(t *testing.T) {
+		for _, l := range latitudes[:len(latitudes)-1] {
+			t.Run(l.String(), func(t *testing.T) {
+				for _, lat := range []float64{-math.Pi / 2, 0, math.Pi / 2} {
+					got := ConvertLatitude(lat, GeodeticLatitude, l, WGS84)
+
+					if eq, ineq := equality.EqualToRadians(got, lat, 1e-15); !eq {
+						equality.ReportInequality(t, "Latitude", ineq)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("it does not change latitudes on a sphere", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			lat := geodetic(t)
+			e := Sphere(6371000)
+
+			for _, l := range latitudes[:len(latitudes)-1] {
+				got := ConvertLatitude(lat, GeodeticLatitude, l, e)
+
+				if eq, ineq := equality.EqualToRadians(got, lat, 1e-15); !eq {
+					equality.ReportInequality(t, l.String(), ineq)
+				}
+			}
+		})
+	})
+
+	t.Run("it returns NaN for unknown kinds of latitude", func(t *testing.T) {
+		if got := ConvertLatitude(0.5, GeodeticLatitude, AuxiliaryLatitude(99), WGS84); !math.IsNaN(got) {
+			t.Errorf("got %v; want NaN", got)
+		}
+		if got := ConvertLatitude(0.5, AuxiliaryLatitude(-1), GeodeticLatitude, WGS84); !math.IsNaN(got) {
+			t.Errorf("got %v; want NaN", got)
+		}
+	})
+}
+
+func Test_AuxiliaryLatitude_String(t *testing.T) {
+	tests := map[AuxiliaryLatitude]string{
+		GeodeticLatitude:      "geodetic",
+		GeocentricLatitude:    "geocentric",
+		ParametricLatitude:    "parametric",
+		AuthalicLatitude:      "authalic",
+		ConformalLatitude:     "conformal",
+		RectifyingLatitude:    "rectifying",
+		IsometricLatitude:     "isometric",
+		AuxiliaryLatitude(99): "AuxiliaryLatitude(99)",
+	}
+
+	for l, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			if got := l.String(); got != want {
+				t.Errorf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
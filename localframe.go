@@ -0,0 +1,96 @@
+package nvector
+
+// nedToENU converts vectors decomposed in the North-East-Down frame N to the
+// East-North-Up frame, and vice versa.
+var nedToENU = Matrix{
+	0, 1, 0,
+	1, 0, 0,
+	0, 0, -1,
+}
+
+// ToRotationMatrixENU converts an n-vector to a rotation matrix for the
+// East-North-Up (ENU) frame.
+//
+// The returned matrix is R_E_ENU, whose columns are the east, north, and up
+// axes of the ENU frame, decomposed in E. Like the North-East-Down frame N
+// returned by ToRotationMatrix, the east and north axes are undefined at the
+// poles, where the same axes as ToRotationMatrix are selected.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToRotationMatrixENU(v Vector, f Matrix) Matrix {
+	return ToRotationMatrix(v, f).Multiply(nedToENU)
+}
+
+// ECEFToNED converts an ECEF position vector to a position vector relative to
+// a reference position, decomposed in the reference position's North-East-Down
+// frame N.
+//
+// f is the coordinate frame in which the ECEF position vector and the
+// reference n-vector are decomposed.
+func ECEFToNED(p Vector, ref Position, e Ellipsoid, f Matrix) Vector {
+	return p.Sub(ToECEF(ref, e, f)).
+		Transform(ToRotationMatrix(ref.Vector, f).Transpose())
+}
+
+// NEDToECEF is the inverse of ECEFToNED.
+//
+// f is the coordinate frame in which the ECEF position vector and the
+// reference n-vector are decomposed.
+func NEDToECEF(d Vector, ref Position, e Ellipsoid, f Matrix) Vector {
+	return d.Transform(ToRotationMatrix(ref.Vector, f)).Add(ToECEF(ref, e, f))
+}
+
+// ECEFToENU converts an ECEF position vector to a position vector relative to
+// a reference position, decomposed in the reference position's East-North-Up
+// frame.
+//
+// f is the coordinate frame in which the ECEF position vector and the
+// reference n-vector are decomposed.
+func ECEFToENU(p Vector, ref Position, e Ellipsoid, f Matrix) Vector {
+	return p.Sub(ToECEF(ref, e, f)).
+		Transform(ToRotationMatrixENU(ref.Vector, f).Transpose())
+}
+
+// ENUToECEF is the inverse of ECEFToENU.
+//
+// f is the coordinate frame in which the ECEF position vector and the
+// reference n-vector are decomposed.
+func ENUToECEF(d Vector, ref Position, e Ellipsoid, f Matrix) Vector {
+	return d.Transform(ToRotationMatrixENU(ref.Vector, f)).Add(ToECEF(ref, e, f))
+}
+
+// DeltaNED finds a delta position vector from a reference n-vector position to
+// a target n-vector position, decomposed in the reference position's
+// North-East-Down frame N.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func DeltaNED(from Position, to Position, e Ellipsoid, f Matrix) Vector {
+	return ECEFToNED(ToECEF(to, e, f), from, e, f)
+}
+
+// DestinationNED finds an n-vector position from a reference n-vector
+// position, and a delta position vector decomposed in the reference position's
+// North-East-Down frame N.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func DestinationNED(from Position, delta Vector, e Ellipsoid, f Matrix) Position {
+	return FromECEF(NEDToECEF(delta, from, e, f), e, f)
+}
+
+// DeltaENU finds a delta position vector from a reference n-vector position to
+// a target n-vector position, decomposed in the reference position's
+// East-North-Up frame.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func DeltaENU(from Position, to Position, e Ellipsoid, f Matrix) Vector {
+	return ECEFToENU(ToECEF(to, e, f), from, e, f)
+}
+
+// DestinationENU finds an n-vector position from a reference n-vector
+// position, and a delta position vector decomposed in the reference position's
+// East-North-Up frame.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func DestinationENU(from Position, delta Vector, e Ellipsoid, f Matrix) Position {
+	return FromECEF(ENUToECEF(delta, from, e, f), e, f)
+}
@@ -0,0 +1,189 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ToRotationMatrixENU(t *testing.T) {
+	t.Run("it swaps the north and east axes, and negates the down axis", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.UnitVector().Draw(t, "nVector")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			ned := ToRotationMatrix(v, f)
+			want := Matrix{
+				ned.XY, ned.XX, -ned.XZ,
+				ned.YY, ned.YX, -ned.YZ,
+				ned.ZY, ned.ZX, -ned.ZZ,
+			}
+
+			got := ToRotationMatrixENU(v, f)
+
+			if eq, ineq := equality.EqualToMatrix(got, want, 0); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it points the up axis along the n-vector", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			v := rapidgen.UnitVector().Draw(t, "nVector")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := Vector{X: 0, Y: 0, Z: 1}.Transform(ToRotationMatrixENU(v, f))
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_ECEFToNED(t *testing.T) {
+	t.Run("it is the inverse of NEDToECEF", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			ref := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "refNVector"),
+				Depth:  rapidgen.Depth(e).Draw(t, "refDepth"),
+			}
+			d := rapidgen.VectorRange(-1e6, 1e6).Draw(t, "delta")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := ECEFToNED(NEDToECEF(d, ref, e, f), ref, e, f)
+
+			if eq, ineq := equality.EqualToVector(got, d, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_ECEFToENU(t *testing.T) {
+	t.Run("it is the inverse of ENUToECEF", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			ref := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "refNVector"),
+				Depth:  rapidgen.Depth(e).Draw(t, "refDepth"),
+			}
+			d := rapidgen.VectorRange(-1e6, 1e6).Draw(t, "delta")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := ECEFToENU(ENUToECEF(d, ref, e, f), ref, e, f)
+
+			if eq, ineq := equality.EqualToVector(got, d, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it swaps the north and east components, and negates the down component", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			ref := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "refNVector"),
+				Depth:  rapidgen.Depth(e).Draw(t, "refDepth"),
+			}
+			p := rapidgen.EcefVector(e).Draw(t, "p")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			ned := ECEFToNED(p, ref, e, f)
+			got := ECEFToENU(p, ref, e, f)
+
+			if eq, ineq := equality.EqualToVector(got, Vector{X: ned.Y, Y: ned.X, Z: -ned.Z}, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_DeltaNED(t *testing.T) {
+	t.Run("it matches example 1", func(t *testing.T) {
+		a := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(1), Longitude: Radians(2)},
+				ZAxisNorth,
+			),
+			Depth: 3,
+		}
+		b := Position{
+			Vector: FromGeodeticCoordinates(
+				GeodeticCoordinates{Latitude: Radians(4), Longitude: Radians(5)},
+				ZAxisNorth,
+			),
+			Depth: 6,
+		}
+		want := Vector{X: 331730.23478089, Y: 332997.87498927, Z: 17404.27136194}
+
+		got := DeltaNED(a, b, WGS84, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToVector(got, want, 1e-8); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+
+		got = DeltaENU(a, b, WGS84, ZAxisNorth)
+		want = Vector{X: want.Y, Y: want.X, Z: -want.Z}
+
+		if eq, ineq := equality.EqualToVector(got, want, 1e-8); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+}
+
+func Test_DestinationNED(t *testing.T) {
+	t.Run("it is the inverse of DeltaNED", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			from := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "fromNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "fromDepth"),
+			}
+			to := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "toNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "toDepth"),
+			}
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := DestinationNED(from, DeltaNED(from, to, e, f), e, f)
+
+			if eq, ineq := equality.EqualToVector(got.Vector, to.Vector, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Depth, to.Depth, 1e-6); !eq {
+				equality.ReportInequality(t, "Depth", ineq)
+			}
+		})
+	})
+}
+
+func Test_DestinationENU(t *testing.T) {
+	t.Run("it is the inverse of DeltaENU", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			from := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "fromNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "fromDepth"),
+			}
+			to := Position{
+				Vector: rapidgen.UnitVector().Draw(t, "toNVector"),
+				Depth:  rapid.Float64Range(-1e5, 1e5).Draw(t, "toDepth"),
+			}
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+
+			got := DestinationENU(from, DeltaENU(from, to, e, f), e, f)
+
+			if eq, ineq := equality.EqualToVector(got.Vector, to.Vector, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Depth, to.Depth, 1e-6); !eq {
+				equality.ReportInequality(t, "Depth", ineq)
+			}
+		})
+	})
+}
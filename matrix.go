@@ -1,5 +1,19 @@
 package nvector
 
+import (
+	"fmt"
+	"math"
+)
+
+// orthonormalizeThreshold is the largest change in any element of the matrix
+// between iterations of Orthonormalize, below which the matrix has converged.
+const orthonormalizeThreshold = 1e-15
+
+// orthonormalizeMaxIterations is the maximum number of iterations of
+// Orthonormalize. Newton's method converges quadratically, so this limit is
+// only reached for matrices that are nearly singular.
+const orthonormalizeMaxIterations = 100
+
 // Matrix is a 3x3 matrix.
 type Matrix struct {
 	XX, XY, XZ float64
@@ -32,3 +46,120 @@ func (m Matrix) Transpose() Matrix {
 		m.XZ, m.YZ, m.ZZ,
 	}
 }
+
+// Determinant returns the determinant of m.
+func (m Matrix) Determinant() float64 {
+	return m.XX*(m.YY*m.ZZ-m.YZ*m.ZY) -
+		m.XY*(m.YX*m.ZZ-m.YZ*m.ZX) +
+		m.XZ*(m.YX*m.ZY-m.YY*m.ZX)
+}
+
+// Inverse returns the inverse of m. It returns an error if m is singular.
+//
+// The inverse of a rotation matrix is its transpose, which is cheaper and more
+// accurate to compute with Transpose.
+func (m Matrix) Inverse() (Matrix, error) {
+	det := m.Determinant()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Matrix{}, fmt.Errorf("matrix is singular (determinant %v)", det)
+	}
+
+	// adjugate divided by the determinant
+	return Matrix{
+		(m.YY*m.ZZ - m.YZ*m.ZY) / det,
+		(m.XZ*m.ZY - m.XY*m.ZZ) / det,
+		(m.XY*m.YZ - m.XZ*m.YY) / det,
+
+		(m.YZ*m.ZX - m.YX*m.ZZ) / det,
+		(m.XX*m.ZZ - m.XZ*m.ZX) / det,
+		(m.XZ*m.YX - m.XX*m.YZ) / det,
+
+		(m.YX*m.ZY - m.YY*m.ZX) / det,
+		(m.XY*m.ZX - m.XX*m.ZY) / det,
+		(m.XX*m.YY - m.XY*m.YX) / det,
+	}, nil
+}
+
+// ValidateRotation returns an error if m is not a rotation matrix, within the
+// tolerance tol.
+//
+// A rotation matrix is orthonormal (its transpose is its inverse), and
+// right-handed (its determinant is 1). This can be used to check a custom
+// coordinate frame matrix before passing it to other functions.
+func (m Matrix) ValidateRotation(tol float64) error {
+	p := m.Transpose().Multiply(m)
+	e := math.Max(
+		math.Max(
+			math.Max(math.Abs(p.XX-1), math.Abs(p.YY-1)),
+			math.Max(math.Abs(p.ZZ-1), math.Abs(p.XY)),
+		),
+		math.Max(math.Abs(p.XZ), math.Abs(p.YZ)),
+	)
+	if !(e <= tol) {
+		return fmt.Errorf("matrix is not orthonormal (error %v)", e)
+	}
+
+	if det := m.Determinant(); !(math.Abs(det-1) <= tol) {
+		return fmt.Errorf("matrix is not right-handed (determinant %v)", det)
+	}
+
+	return nil
+}
+
+// Orthonormalize returns the rotation matrix closest to m, which can be used
+// to correct the numerical drift of an integrated rotation matrix. It returns
+// an error if m is singular, is closer to a reflection than a rotation, or is
+// too badly conditioned to converge.
+//
+// The closest rotation matrix is the orthogonal factor of the polar
+// decomposition of m, found by Newton's method.
+//
+// See: https://doi.org/10.1137/0907079
+func (m Matrix) Orthonormalize() (Matrix, error) {
+	if det := m.Determinant(); !(det > 0) {
+		return Matrix{}, fmt.Errorf(
+			"matrix cannot be orthonormalized (determinant %v)",
+			det,
+		)
+	}
+
+	x := m
+	for range orthonormalizeMaxIterations {
+		inv, err := x.Inverse()
+		if err != nil {
+			return Matrix{}, err
+		}
+
+		// X = (X + X^-T) / 2
+		it := inv.Transpose()
+		next := Matrix{
+			(x.XX + it.XX) / 2, (x.XY + it.XY) / 2, (x.XZ + it.XZ) / 2,
+			(x.YX + it.YX) / 2, (x.YY + it.YY) / 2, (x.YZ + it.YZ) / 2,
+			(x.ZX + it.ZX) / 2, (x.ZY + it.ZY) / 2, (x.ZZ + it.ZZ) / 2,
+		}
+
+		d := math.Max(
+			math.Max(
+				math.Max(math.Abs(next.XX-x.XX), math.Abs(next.XY-x.XY)),
+				math.Max(math.Abs(next.XZ-x.XZ), math.Abs(next.YX-x.YX)),
+			),
+			math.Max(
+				math.Max(math.Abs(next.YY-x.YY), math.Abs(next.YZ-x.YZ)),
+				math.Max(
+					math.Abs(next.ZX-x.ZX),
+					math.Max(math.Abs(next.ZY-x.ZY), math.Abs(next.ZZ-x.ZZ)),
+				),
+			),
+		)
+		x = next
+
+		if d <= orthonormalizeThreshold {
+			return x, nil
+		}
+	}
+
+	return Matrix{}, fmt.Errorf(
+		"matrix did not converge after %d iterations of orthonormalization",
+		orthonormalizeMaxIterations,
+	)
+}
@@ -0,0 +1,243 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Matrix_Determinant(t *testing.T) {
+	tests := map[string]struct {
+		m    Matrix
+		want float64
+	}{
+		"identity":   {XAxisNorth, 1},
+		"ECEF frame": {ZAxisNorth, 1},
+		"reflection": {Matrix{XX: -1, YY: 1, ZZ: 1}, -1},
+		"scaling":    {Matrix{XX: 2, YY: 3, ZZ: 4}, 24},
+		"singular":   {Matrix{1, 2, 3, 4, 5, 6, 7, 8, 9}, 0},
+		"general":    {Matrix{2, -3, 1, 2, 0, -1, 1, 4, 5}, 49},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if eq, ineq := equality.EqualToFloat64(tt.m.Determinant(), tt.want, 1e-14); !eq {
+				equality.ReportInequality(t, "Determinant", ineq)
+			}
+		})
+	}
+
+	t.Run("it returns 1 for rotation matrices", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			if eq, ineq := equality.EqualToFloat64(r.Determinant(), 1, 1e-14); !eq {
+				equality.ReportInequality(t, "Determinant", ineq)
+			}
+		})
+	})
+}
+
+func Test_Matrix_Inverse(t *testing.T) {
+	t.Run("it produces the identity when multiplied", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			m := Matrix{
+				XX: rapid.Float64Range(-10, 10).Draw(t, "XX"),
+				XY: rapid.Float64Range(-10, 10).Draw(t, "XY"),
+				XZ: rapid.Float64Range(-10, 10).Draw(t, "XZ"),
+				YX: rapid.Float64Range(-10, 10).Draw(t, "YX"),
+				YY: rapid.Float64Range(-10, 10).Draw(t, "YY"),
+				YZ: rapid.Float64Range(-10, 10).Draw(t, "YZ"),
+				ZX: rapid.Float64Range(-10, 10).Draw(t, "ZX"),
+				ZY: rapid.Float64Range(-10, 10).Draw(t, "ZY"),
+				ZZ: rapid.Float64Range(-10, 10).Draw(t, "ZZ"),
+			}
+			if math.Abs(m.Determinant()) < 1e-3 {
+				// skip nearly singular matrices, where errors are magnified
+				t.Skip()
+			}
+
+			inv, err := m.Inverse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToMatrix(m.Multiply(inv), XAxisNorth, 1e-9); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToMatrix(inv.Multiply(m), XAxisNorth, 1e-9); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it matches the transpose of rotation matrices", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			got, err := r.Inverse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToMatrix(got, r.Transpose(), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error for singular matrices", func(t *testing.T) {
+		tests := map[string]Matrix{
+			"zero":         {},
+			"rank 2":       {1, 2, 3, 4, 5, 6, 7, 8, 9},
+			"not a number": {XX: math.NaN(), YY: 1, ZZ: 1},
+		}
+
+		for name, m := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := m.Inverse(); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_Matrix_ValidateRotation(t *testing.T) {
+	t.Run("it accepts rotation matrices", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			if err := r.ValidateRotation(1e-12); err != nil {
+				t.Error(err)
+			}
+		})
+	})
+
+	t.Run("it accepts the coordinate frames", func(t *testing.T) {
+		for name, f := range map[string]Matrix{
+			"ZAxisNorth": ZAxisNorth,
+			"XAxisNorth": XAxisNorth,
+		} {
+			if err := f.ValidateRotation(0); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("it rejects invalid matrices", func(t *testing.T) {
+		tests := map[string]Matrix{
+			"reflection":   {XX: -1, YY: 1, ZZ: 1},
+			"scaled":       {XX: 1.01, YY: 1.01, ZZ: 1.01},
+			"sheared":      {XX: 1, XY: 0.01, YY: 1, ZZ: 1},
+			"swapped axes": {0, 1, 0, 1, 0, 0, 0, 0, 1},
+			"not a number": {XX: math.NaN(), YY: 1, ZZ: 1},
+		}
+
+		for name, m := range tests {
+			t.Run(name, func(t *testing.T) {
+				if err := m.ValidateRotation(1e-6); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_Matrix_Orthonormalize(t *testing.T) {
+	t.Run("it leaves rotation matrices unchanged", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			got, err := r.Orthonormalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToMatrix(got, r, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it corrects drift", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+			noise := func(name string) float64 {
+				return rapid.Float64Range(-1e-3, 1e-3).Draw(t, name)
+			}
+			m := Matrix{
+				XX: r.XX + noise("XX"), XY: r.XY + noise("XY"), XZ: r.XZ + noise("XZ"),
+				YX: r.YX + noise("YX"), YY: r.YY + noise("YY"), YZ: r.YZ + noise("YZ"),
+				ZX: r.ZX + noise("ZX"), ZY: r.ZY + noise("ZY"), ZZ: r.ZZ + noise("ZZ"),
+			}
+
+			got, err := m.Orthonormalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := got.ValidateRotation(1e-14); err != nil {
+				t.Error(err)
+			}
+			if angle := RotationAngle(got, r); angle > 1e-2 {
+				t.Errorf("got angle %v from the original rotation; want <= 1e-2", angle)
+			}
+		})
+	})
+
+	t.Run("it finds the closest rotation to a scaled rotation", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+			s := rapid.Float64Range(0.1, 10).Draw(t, "scale")
+			m := Matrix{
+				r.XX * s, r.XY * s, r.XZ * s,
+				r.YX * s, r.YY * s, r.YZ * s,
+				r.ZX * s, r.ZY * s, r.ZZ * s,
+			}
+
+			got, err := m.Orthonormalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToMatrix(got, r, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it corrects badly conditioned matrices", func(t *testing.T) {
+		r := EulerZYXToRotationMatrix(EulerZYX{Z: 0.3, Y: -0.2, X: 1.1})
+		m := Matrix{XX: 1, YY: 1e-8, ZZ: 1e-10}.Multiply(r)
+
+		got, err := m.Orthonormalize()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToMatrix(got, r, 1e-14); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it returns an error for reflections and singular matrices", func(t *testing.T) {
+		tests := map[string]Matrix{
+			"reflection":      {XX: -1, YY: 1, ZZ: 1},
+			"singular":        {1, 2, 3, 4, 5, 6, 7, 8, 9},
+			"nearly singular": {XX: 1, YY: 1, ZZ: 1e-300},
+		}
+
+		for name, m := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := m.Orthonormalize(); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
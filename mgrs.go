@@ -0,0 +1,411 @@
+package nvector
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mgrsLatitudeBands are the MGRS latitude band letters, from 80°S to 84°N.
+	// X is repeated because the northernmost band spans 12° instead of 8°.
+	mgrsLatitudeBands = "CDEFGHJKLMNPQRSTUVWXX"
+)
+
+var (
+	// mgrsColumnLetters are the 100km square column letters, which repeat every
+	// 3 zones.
+	mgrsColumnLetters = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	// mgrsRowLetters are the 100km square row letters, which alternate between
+	// odd and even zones.
+	mgrsRowLetters = [2]string{"ABCDEFGHJKLMNPQRSTUV", "FGHJKLMNPQRSTUVABCDE"}
+
+	// mgrsPolarColumnLetters are the 100km square column letters in the polar
+	// regions, from west to east.
+	mgrsPolarColumnLetters = "ABCFGHJKLPQRSTUXYZ"
+	// mgrsPolarRowLetters are the 100km square row letters in the polar regions,
+	// from south to north.
+	mgrsPolarRowLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	// mgrsPolarZones are the 100km square lettering schemes of the polar
+	// regions, keyed by their band letter. A and B are west and east of the
+	// south pole, and Y and Z are west and east of the north pole.
+	//
+	// See: https://github.com/ngageoint/geotrans/blob/master/CCS/src/dtcc/CoordinateSystems/mgrs/MGRS.cpp
+	mgrsPolarZones = map[byte]struct {
+		firstColumn, lastColumn, lastRow byte
+		falseEasting, falseNorthing      float64
+	}{
+		'A': {'J', 'Z', 'Z', 800000, 800000},
+		'B': {'A', 'R', 'Z', 2000000, 800000},
+		'Y': {'J', 'Z', 'P', 800000, 1300000},
+		'Z': {'A', 'J', 'P', 2000000, 1300000},
+	}
+
+	mgrsPattern = regexp.MustCompile(
+		`^(\d{1,2})?([A-HJ-NP-Z])([A-HJ-NP-Z])([A-HJ-NP-Z])(\d{0,10})$`,
+	)
+)
+
+// MGRSCoordinates is a position expressed as a Military Grid Reference System
+// (MGRS) grid reference.
+//
+// Easting and Northing are given in meters, relative to the south-west corner
+// of the 100km grid square.
+//
+// The 100km grid square letters follow the standard "AA" lettering scheme used
+// with WGS84.
+//
+// In the polar regions, which use the Universal Polar Stereographic (UPS)
+// coordinate system, Zone is 0 and Band is one of A, B, Y, or Z.
+type MGRSCoordinates struct {
+	// Zone is the UTM zone, or 0 in the polar regions.
+	Zone int
+	// Band is the latitude band letter.
+	Band byte
+	// Column is the 100km grid square column letter.
+	Column byte
+	// Row is the 100km grid square row letter.
+	Row byte
+
+	Easting, Northing float64
+}
+
+// ParseMGRS parses an MGRS grid reference from a string.
+//
+// The string must contain the zone, band, 100km grid square letters, and an
+// even number of up to 10 digits. Whitespace is ignored, so "31U DQ 48251
+// 11932" and "31UDQ4825111932" are equivalent. Fewer digits represent a lower
+// precision, e.g. "31U DQ 482 119" is a 100m grid square. Grid references in
+// the polar regions have no zone, e.g. "Z AH 00000 00000".
+func ParseMGRS(s string) (MGRSCoordinates, error) {
+	compact := strings.ToUpper(strings.Join(strings.Fields(s), ""))
+
+	m := mgrsPattern.FindStringSubmatch(compact)
+	if m == nil || len(m[5])%2 != 0 {
+		return MGRSCoordinates{}, fmt.Errorf("invalid MGRS grid reference %q", s)
+	}
+
+	var zone int
+	if m[1] == "" {
+		if _, ok := mgrsPolarZones[m[2][0]]; !ok {
+			return MGRSCoordinates{}, fmt.Errorf("invalid MGRS polar band %q", m[2])
+		}
+	} else {
+		zone, _ = strconv.Atoi(m[1])
+		if err := checkUTMZone(zone); err != nil {
+			return MGRSCoordinates{}, err
+		}
+		if strings.IndexByte(mgrsLatitudeBands, m[2][0]) < 0 {
+			return MGRSCoordinates{}, fmt.Errorf("invalid MGRS band %q", m[2])
+		}
+	}
+
+	c := MGRSCoordinates{
+		Zone:   zone,
+		Band:   m[2][0],
+		Column: m[3][0],
+		Row:    m[4][0],
+	}
+
+	if digits := len(m[5]) / 2; digits > 0 {
+		scale := math.Pow10(5 - digits)
+		e, _ := strconv.Atoi(m[5][:digits])
+		n, _ := strconv.Atoi(m[5][digits:])
+		c.Easting = float64(e) * scale
+		c.Northing = float64(n) * scale
+	}
+
+	return c, nil
+}
+
+// Format formats an MGRS grid reference as a string, e.g. "31U DQ 48251
+// 11932".
+//
+// digits is the number of digits used for each of the easting and northing,
+// from 0 (100km precision) to 5 (1m precision). As is conventional for MGRS,
+// the easting and northing are truncated rather than rounded, so that the
+// grid reference identifies the grid square that contains the position.
+func (c MGRSCoordinates) Format(digits int) string {
+	digits = max(0, min(5, digits))
+
+	sq := fmt.Sprintf("%02d%c %c%c", c.Zone, c.Band, c.Column, c.Row)
+	if c.Zone == 0 {
+		sq = fmt.Sprintf("%c %c%c", c.Band, c.Column, c.Row)
+	}
+	if digits == 0 {
+		return sq
+	}
+
+	scale := math.Pow10(5 - digits)
+
+	return fmt.Sprintf(
+		"%s %0*d %0*d",
+		sq,
+		digits,
+		int(math.Floor(c.Easting/scale)),
+		digits,
+		int(math.Floor(c.Northing/scale)),
+	)
+}
+
+// String formats an MGRS grid reference as a string with 1m precision.
+func (c MGRSCoordinates) String() string {
+	return c.Format(5)
+}
+
+// ToMGRS converts an n-vector to an MGRS grid reference.
+//
+// Positions north of 84°N and south of 80°S are converted using the UPS polar
+// regions.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToMGRS(v Vector, e Ellipsoid, f Matrix) (MGRSCoordinates, error) {
+	lat := Degrees(ToGeodeticCoordinates(v, f).Latitude)
+	if lat < -80 || lat > 84 {
+		return UPSToMGRS(ToUPS(v, e, f))
+	}
+
+	u, err := ToUTM(v, e, f)
+	if err != nil {
+		return MGRSCoordinates{}, err
+	}
+
+	return utmToMGRS(u, lat)
+}
+
+// FromMGRS converts an MGRS grid reference to an n-vector.
+//
+// The n-vector is at the south-west corner of the grid square identified by the
+// grid reference, at its precision. An error is returned if the grid reference
+// is invalid.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func FromMGRS(c MGRSCoordinates, e Ellipsoid, f Matrix) (Vector, error) {
+	if c.Zone == 0 {
+		u, err := MGRSToUPS(c)
+		if err != nil {
+			return Vector{}, err
+		}
+
+		return FromUPS(u, e, f)
+	}
+
+	u, err := MGRSToUTM(c, e)
+	if err != nil {
+		return Vector{}, err
+	}
+
+	return FromUTM(u, e, f)
+}
+
+// UTMToMGRS converts UTM coordinates to an MGRS grid reference.
+//
+// An error is returned if the UTM coordinates are invalid, or outside the UTM
+// latitude limits of 80°S to 84°N.
+func UTMToMGRS(c UTMCoordinates, e Ellipsoid) (MGRSCoordinates, error) {
+	v, err := FromUTM(c, e, XAxisNorth)
+	if err != nil {
+		return MGRSCoordinates{}, err
+	}
+
+	lat := Degrees(ToGeodeticCoordinates(v, XAxisNorth).Latitude)
+	if lat < -80 || lat > 84 {
+		return MGRSCoordinates{}, fmt.Errorf(
+			"latitude %v° is outside the UTM limits of 80°S to 84°N",
+			lat,
+		)
+	}
+
+	return utmToMGRS(c, lat)
+}
+
+// MGRSToUTM converts an MGRS grid reference to UTM coordinates.
+//
+// The UTM coordinates are at the south-west corner of the grid square
+// identified by the grid reference, at its precision. An error is returned if
+// the grid reference is invalid.
+//
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/mgrs.js
+func MGRSToUTM(c MGRSCoordinates, e Ellipsoid) (UTMCoordinates, error) {
+	if err := checkUTMZone(c.Zone); err != nil {
+		return UTMCoordinates{}, err
+	}
+
+	band := strings.IndexByte(mgrsLatitudeBands, c.Band)
+	if band < 0 {
+		return UTMCoordinates{}, fmt.Errorf("invalid MGRS band %q", c.Band)
+	}
+
+	col := strings.IndexByte(mgrsColumnLetters[(c.Zone-1)%3], c.Column)
+	if col < 0 {
+		return UTMCoordinates{}, fmt.Errorf(
+			"invalid MGRS column letter %q for zone %d",
+			c.Column,
+			c.Zone,
+		)
+	}
+
+	row := strings.IndexByte(mgrsRowLetters[(c.Zone-1)%2], c.Row)
+	if row < 0 {
+		return UTMCoordinates{}, fmt.Errorf("invalid MGRS row letter %q", c.Row)
+	}
+
+	h := NorthernHemisphere
+	if c.Band < 'N' {
+		h = SouthernHemisphere
+	}
+
+	// The northing of the bottom of the band, extended to include the whole of
+	// the bottom-most 100km square. Parallels curve towards the pole away from
+	// the central meridian, so in the southern hemisphere the bottom of the band
+	// is lowest at the edge of the zone:
+	p := utmProjection(e, c.Zone, h)
+	lon := p.Origin.Longitude
+	if h == SouthernHemisphere {
+		lon += Radians(3)
+	}
+	bandOrigin, _ := p.Forward(
+		FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(float64((band - 10) * 8)), lon},
+			XAxisNorth,
+		),
+		XAxisNorth,
+	)
+	bandNorthing := math.Floor(bandOrigin.Northing/100e3) * 100e3
+
+	// Row letters repeat every 2000km, so add enough 2000km blocks to get into
+	// the band:
+	n := float64(row)*100e3 + c.Northing
+	for n < bandNorthing {
+		n += 2000e3
+	}
+
+	return UTMCoordinates{
+		Zone:       c.Zone,
+		Hemisphere: h,
+		Easting:    float64(col+1)*100e3 + c.Easting,
+		Northing:   n,
+	}, nil
+}
+
+// UPSToMGRS converts UPS coordinates to an MGRS grid reference in one of the
+// polar regions.
+//
+// An error is returned if the UPS coordinates are invalid, or outside the grid
+// squares of the polar regions.
+func UPSToMGRS(c UPSCoordinates) (MGRSCoordinates, error) {
+	var band byte
+	switch {
+	case c.Hemisphere == NorthernHemisphere && c.Easting < upsFalseEasting:
+		band = 'Y'
+	case c.Hemisphere == NorthernHemisphere:
+		band = 'Z'
+	case c.Hemisphere == SouthernHemisphere && c.Easting < upsFalseEasting:
+		band = 'A'
+	case c.Hemisphere == SouthernHemisphere:
+		band = 'B'
+	default:
+		return MGRSCoordinates{}, fmt.Errorf("invalid UPS hemisphere %v", c.Hemisphere)
+	}
+	z := mgrsPolarZones[band]
+
+	col := strings.IndexByte(mgrsPolarColumnLetters, z.firstColumn) +
+		int(math.Floor((c.Easting-z.falseEasting)/100e3))
+	row := int(math.Floor((c.Northing - z.falseNorthing) / 100e3))
+
+	if c.Easting < z.falseEasting ||
+		col > strings.IndexByte(mgrsPolarColumnLetters, z.lastColumn) ||
+		row < 0 ||
+		row > strings.IndexByte(mgrsPolarRowLetters, z.lastRow) {
+		return MGRSCoordinates{}, fmt.Errorf(
+			"UPS coordinates %v are outside the MGRS grid squares of band %c",
+			c,
+			band,
+		)
+	}
+
+	return MGRSCoordinates{
+		Zone:     0,
+		Band:     band,
+		Column:   mgrsPolarColumnLetters[col],
+		Row:      mgrsPolarRowLetters[row],
+		Easting:  math.Mod(c.Easting, 100e3),
+		Northing: math.Mod(c.Northing, 100e3),
+	}, nil
+}
+
+// MGRSToUPS converts an MGRS grid reference in one of the polar regions to UPS
+// coordinates.
+//
+// The UPS coordinates are at the south-west corner of the grid square
+// identified by the grid reference, at its precision. An error is returned if
+// the grid reference is invalid, or not in one of the polar regions.
+//
+// See: https://github.com/ngageoint/geotrans/blob/master/CCS/src/dtcc/CoordinateSystems/mgrs/MGRS.cpp
+func MGRSToUPS(c MGRSCoordinates) (UPSCoordinates, error) {
+	z, ok := mgrsPolarZones[c.Band]
+	if c.Zone != 0 || !ok {
+		return UPSCoordinates{}, fmt.Errorf(
+			"MGRS zone %d band %q is not a polar region",
+			c.Zone,
+			c.Band,
+		)
+	}
+
+	first := strings.IndexByte(mgrsPolarColumnLetters, z.firstColumn)
+	col := strings.IndexByte(mgrsPolarColumnLetters, c.Column)
+	if col < first ||
+		col > strings.IndexByte(mgrsPolarColumnLetters, z.lastColumn) {
+		return UPSCoordinates{}, fmt.Errorf(
+			"invalid MGRS column letter %q for band %c",
+			c.Column,
+			c.Band,
+		)
+	}
+
+	row := strings.IndexByte(mgrsPolarRowLetters, c.Row)
+	if row < 0 || row > strings.IndexByte(mgrsPolarRowLetters, z.lastRow) {
+		return UPSCoordinates{}, fmt.Errorf(
+			"invalid MGRS row letter %q for band %c",
+			c.Row,
+			c.Band,
+		)
+	}
+
+	h := NorthernHemisphere
+	if c.Band < 'N' {
+		h = SouthernHemisphere
+	}
+
+	return UPSCoordinates{
+		Hemisphere: h,
+		Easting:    z.falseEasting + float64(col-first)*100e3 + c.Easting,
+		Northing:   z.falseNorthing + float64(row)*100e3 + c.Northing,
+	}, nil
+}
+
+func utmToMGRS(c UTMCoordinates, lat float64) (MGRSCoordinates, error) {
+	band := mgrsLatitudeBands[int(math.Floor(lat/8+10))]
+
+	col := int(math.Floor(c.Easting / 100e3))
+	if col < 1 || col > 8 {
+		return MGRSCoordinates{}, fmt.Errorf(
+			"UTM easting %v is outside the MGRS grid squares of zone %d",
+			c.Easting,
+			c.Zone,
+		)
+	}
+
+	row := int(math.Floor(c.Northing/100e3)) % 20
+
+	return MGRSCoordinates{
+		Zone:     c.Zone,
+		Band:     band,
+		Column:   mgrsColumnLetters[(c.Zone-1)%3][col-1],
+		Row:      mgrsRowLetters[(c.Zone-1)%2][row],
+		Easting:  math.Mod(c.Easting, 100e3),
+		Northing: math.Mod(c.Northing, 100e3),
+	}, nil
+}
@@ -0,0 +1,362 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ToMGRS(t *testing.T) {
+	tests := map[string]struct {
+		lat, lon float64
+		want     string
+	}{
+		"origin":         {0, 0, "31N AA 66021 00000"},
+		"Eiffel Tower":   {48.8582, 2.2945, "31U DQ 48251 11932"},
+		"Sydney":         {-33.857, 151.215, "56H LH 34873 52266"},
+		"White House":    {38.8977, -77.0365, "18S UJ 23394 07395"},
+		"Rio de Janeiro": {-22.9519, -43.2106, "23K PQ 83466 60687"},
+		"Norway":         {60.39135, 5.3249, "32V KN 97508 00645"},
+		"Svalbard":       {78.2208, 15.6463, "33X WG 14728 83092"},
+		"north pole":     {90, 0, "Z AH 00000 00000"},
+		"south pole":     {-90, 0, "B AN 00000 00000"},
+		"band bottom":    {-63.9999999, -179.9999, "01E CJ 53309 99533"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			got, err := ToMGRS(v, WGS84, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("got %q; want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_FromMGRS(t *testing.T) {
+	t.Run("it is the inverse of ToMGRS", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			c := GeodeticCoordinates{
+				Latitude:  Radians(rapid.Float64Range(-79.999, 83.999).Draw(t, "latitude")),
+				Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude")),
+			}
+			v := FromGeodeticCoordinates(c, f)
+
+			m, err := ToMGRS(v, e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := FromMGRS(m, e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it is the inverse of ToMGRS at the bottom of each band", func(t *testing.T) {
+		tests := map[string]float64{
+			"band C": -80,
+			"band D": -72,
+			"band E": -64,
+			"band F": -56,
+			"band G": -48,
+			"band H": -40,
+			"band J": -32,
+			"band K": -24,
+			"band L": -16,
+			"band M": -8,
+			"band N": 0,
+			"band P": 8,
+			"band Q": 16,
+			"band R": 24,
+			"band S": 32,
+			"band T": 40,
+			"band U": 48,
+			"band V": 56,
+			"band W": 64,
+			"band X": 72,
+		}
+
+		for name, lat := range tests {
+			t.Run(name, func(t *testing.T) {
+				for zone := 1; zone <= 60; zone++ {
+					// the central meridian and the edges of the zone
+					cm := float64(zone*6 - 183)
+					for _, lon := range []float64{cm - 2.9999, cm, cm + 2.9999} {
+						v := FromGeodeticCoordinates(
+							GeodeticCoordinates{Radians(lat + 1e-7), Radians(lon)},
+							ZAxisNorth,
+						)
+
+						m, err := ToMGRS(v, WGS84, ZAxisNorth)
+						if err != nil {
+							t.Fatal(err)
+						}
+						got, err := FromMGRS(m, WGS84, ZAxisNorth)
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+							t.Errorf("%v at longitude %v:", m, lon)
+							equality.ReportInequalities(t, ineq)
+						}
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("it is the inverse of ToMGRS in the polar regions", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			lat := rapid.Float64Range(84.001, 90).Draw(t, "latitude")
+			if rapid.Bool().Draw(t, "south") {
+				lat = -rapid.Float64Range(80.001, 90).Draw(t, "latitude")
+			}
+			c := GeodeticCoordinates{
+				Latitude:  Radians(lat),
+				Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude")),
+			}
+			v := FromGeodeticCoordinates(c, f)
+
+			m, err := ToMGRS(v, e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Zone != 0 {
+				t.Fatalf("got zone %d; want 0", m.Zone)
+			}
+			got, err := FromMGRS(m, e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns the south-west corner of the grid square", func(t *testing.T) {
+		m, err := ParseMGRS("31U DQ 482 119")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := MGRSToUTM(m, WGS84)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := UTMCoordinates{31, NorthernHemisphere, 448200, 5411900}
+		if got != want {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it returns an error for invalid grid squares", func(t *testing.T) {
+		for _, m := range []MGRSCoordinates{
+			{Zone: 0, Band: 'U', Column: 'D', Row: 'Q'},
+			{Zone: 31, Band: 'A', Column: 'D', Row: 'Q'},
+			{Zone: 31, Band: 'U', Column: 'J', Row: 'Q'},
+			{Zone: 31, Band: 'U', Column: 'D', Row: 'W'},
+		} {
+			if _, err := FromMGRS(m, WGS84, ZAxisNorth); err == nil {
+				t.Errorf("FromMGRS(%v): expected an error", m)
+			}
+		}
+	})
+}
+
+func Test_UTMToMGRS(t *testing.T) {
+	t.Run("it converts UTM coordinates to MGRS", func(t *testing.T) {
+		u := UTMCoordinates{56, SouthernHemisphere, 334873.199, 6252266.092}
+
+		got, err := UTMToMGRS(u, WGS84)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := "56H LH 34873 52266"; got.String() != want {
+			t.Errorf("got %q; want %q", got.String(), want)
+		}
+	})
+
+	t.Run("it returns an error outside the grid squares", func(t *testing.T) {
+		u := UTMCoordinates{31, NorthernHemisphere, 50000, 0}
+
+		if _, err := UTMToMGRS(u, WGS84); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_UPSToMGRS(t *testing.T) {
+	t.Run("it converts UPS coordinates to MGRS", func(t *testing.T) {
+		u := UPSCoordinates{SouthernHemisphere, 1912345.6, 2123456.7}
+
+		got, err := UPSToMGRS(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := "A ZP 12345 23456"; got.String() != want {
+			t.Errorf("got %q; want %q", got.String(), want)
+		}
+	})
+
+	t.Run("it returns an error outside the grid squares", func(t *testing.T) {
+		u := UPSCoordinates{NorthernHemisphere, 2000000, 3000000}
+
+		if _, err := UPSToMGRS(u); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_MGRSToUPS(t *testing.T) {
+	t.Run("it converts MGRS to UPS coordinates", func(t *testing.T) {
+		m, err := ParseMGRS("Z AH 12345 67890")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := MGRSToUPS(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := UPSCoordinates{NorthernHemisphere, 2012345, 2067890}
+		if got != want {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it returns an error outside the polar regions", func(t *testing.T) {
+		m := MGRSCoordinates{Zone: 31, Band: 'U', Column: 'D', Row: 'Q'}
+
+		if _, err := MGRSToUPS(m); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_ParseMGRS(t *testing.T) {
+	t.Run("it parses MGRS grid references", func(t *testing.T) {
+		want := MGRSCoordinates{
+			Zone:     31,
+			Band:     'U',
+			Column:   'D',
+			Row:      'Q',
+			Easting:  48251,
+			Northing: 11932,
+		}
+
+		for _, s := range []string{
+			"31U DQ 48251 11932",
+			"31UDQ4825111932",
+			"31u dq 48251 11932",
+		} {
+			got, err := ParseMGRS(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != want {
+				t.Errorf("ParseMGRS(%q) = %v; want %v", s, got, want)
+			}
+		}
+	})
+
+	t.Run("it parses polar MGRS grid references", func(t *testing.T) {
+		want := MGRSCoordinates{
+			Band:     'Z',
+			Column:   'A',
+			Row:      'H',
+			Easting:  12300,
+			Northing: 45600,
+		}
+
+		for _, s := range []string{"Z AH 123 456", "ZAH123456"} {
+			got, err := ParseMGRS(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != want {
+				t.Errorf("ParseMGRS(%q) = %v; want %v", s, got, want)
+			}
+		}
+	})
+
+	t.Run("it returns an error for invalid strings", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"31U DQ 4825 11932",
+			"61U DQ 48251 11932",
+			"31I DQ 48251 11932",
+			"31U DO 48251 11932",
+			"31A DQ 48251 11932",
+			"U DQ 48251 11932",
+		} {
+			if _, err := ParseMGRS(s); err == nil {
+				t.Errorf("ParseMGRS(%q): expected an error", s)
+			}
+		}
+	})
+}
+
+func Test_MGRSCoordinates_Format(t *testing.T) {
+	c := MGRSCoordinates{
+		Zone:     4,
+		Band:     'Q',
+		Column:   'F',
+		Row:      'J',
+		Easting:  12345.9,
+		Northing: 67890.9,
+	}
+
+	tests := map[int]string{
+		0: "04Q FJ",
+		1: "04Q FJ 1 6",
+		3: "04Q FJ 123 678",
+		5: "04Q FJ 12345 67890",
+	}
+
+	polar := MGRSCoordinates{
+		Band:     'Y',
+		Column:   'X',
+		Row:      'P',
+		Easting:  12345.9,
+		Northing: 67890.9,
+	}
+	if got, want := polar.Format(2), "Y XP 12 67"; got != want {
+		t.Errorf("Format(2) = %q; want %q", got, want)
+	}
+
+	for digits, want := range tests {
+		if got := c.Format(digits); got != want {
+			t.Errorf("Format(%d) = %q; want %q", digits, got, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package nvector
+
+import (
+	"errors"
+	"math"
+)
+
+// Orthographic is a spherical orthographic projection centered on a position.
+//
+// Positions are projected perpendicularly onto the plane tangent to the sphere
+// at the center of the projection, showing the sphere as it appears from an
+// infinite distance. Only the hemisphere centered on the center of the
+// projection is visible, and can be projected.
+//
+// The projected y-axis points north from the center. If the center is at a
+// pole, north is the direction of the x-axis of the N frame returned by
+// ToRotationMatrix.
+//
+// See: https://en.wikipedia.org/wiki/Orthographic_map_projection
+type Orthographic struct {
+	// Radius is the radius of the sphere in meters.
+	Radius float64
+	// Center is the n-vector at the center of the projection.
+	Center Vector
+	// FalseEasting and FalseNorthing are the projected coordinates of the
+	// center, in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// Visible returns true if an n-vector is in the hemisphere centered on the
+// center of the projection, including its edge.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p Orthographic) Visible(v Vector, f Matrix) bool {
+	return v.Transform(ToRotationMatrix(p.Center, f).Transpose()).Z <= 0
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the n-vector is not visible.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p Orthographic) Forward(v Vector, f Matrix) (ProjectedCoordinates, error) {
+	n := v.Transform(ToRotationMatrix(p.Center, f).Transpose())
+	if n.Z > 0 {
+		return ProjectedCoordinates{}, errors.New(
+			"n-vector is on the far side of the projection",
+		)
+	}
+
+	return ProjectedCoordinates{
+		p.FalseEasting + p.Radius*n.Y,
+		p.FalseNorthing + p.Radius*n.X,
+	}, nil
+}
+
+// Inverse converts projected coordinates to a visible n-vector.
+//
+// An error is returned if the coordinates are outside the circle that contains
+// all projected positions.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func (p Orthographic) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	x := (c.Easting - p.FalseEasting) / p.Radius
+	y := (c.Northing - p.FalseNorthing) / p.Radius
+
+	rhoSq := x*x + y*y
+	if rhoSq > 1 {
+		return Vector{}, errors.New(
+			"projected coordinates are outside the projection",
+		)
+	}
+
+	return Vector{y, x, -math.Sqrt(1 - rhoSq)}.
+		Transform(ToRotationMatrix(p.Center, f)), nil
+}
+
+// Distortion returns the scale factors and meridian convergence of the
+// projection at an n-vector.
+//
+// An error is returned if the n-vector is not visible.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p Orthographic) Distortion(v Vector, f Matrix) (Distortion, error) {
+	n := v.Transform(ToRotationMatrix(p.Center, f).Transpose())
+	if n.Z > 0 {
+		return Distortion{}, errors.New(
+			"n-vector is on the far side of the projection",
+		)
+	}
+	if n.X == 0 && n.Y == 0 {
+		return Distortion{1, 1, 0}, nil
+	}
+
+	return azimuthalDistortion(
+		-n.Z,
+		1,
+		math.Atan2(n.Y, n.X),
+		awayFrom(p.Center, v, f),
+	), nil
+}
@@ -0,0 +1,177 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Orthographic_Visible(t *testing.T) {
+	p := Orthographic{
+		Radius: 6371e3,
+		Center: FromGeodeticCoordinates(GeodeticCoordinates{Radians(45), 0}, ZAxisNorth),
+	}
+
+	tests := map[string]struct {
+		lat, lon float64
+		want     bool
+	}{
+		"center":           {45, 0, true},
+		"near side":        {0, 60, true},
+		"far side":         {-45, 180, false},
+		"behind the north": {10, 180, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			if got := p.Visible(v, ZAxisNorth); got != tt.want {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Orthographic_Forward(t *testing.T) {
+	t.Run("it projects positions perpendicularly onto the plane", func(t *testing.T) {
+		p := Orthographic{
+			Radius:        6371e3,
+			Center:        FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+			FalseEasting:  1000,
+			FalseNorthing: 2000,
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(30), Radians(60)},
+			ZAxisNorth,
+		)
+
+		got, err := p.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := ProjectedCoordinates{
+			1000 + 6371e3*math.Cos(Radians(30))*math.Sin(Radians(60)),
+			2000 + 6371e3*math.Sin(Radians(30)),
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Easting, want.Easting, 1e-6); !eq {
+			equality.ReportInequality(t, "Easting", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Northing, want.Northing, 1e-6); !eq {
+			equality.ReportInequality(t, "Northing", ineq)
+		}
+	})
+
+	t.Run("it returns an error on the far side", func(t *testing.T) {
+		p := Orthographic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+
+		for _, lon := range []float64{91, 120, 180} {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{0, Radians(lon)},
+				ZAxisNorth,
+			)
+
+			if _, err := p.Forward(v, ZAxisNorth); err == nil {
+				t.Errorf("longitude %v: expected an error", lon)
+			}
+		}
+	})
+}
+
+func Test_Orthographic_Inverse(t *testing.T) {
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			p := Orthographic{
+				Radius:        6371e3,
+				Center:        rapidgen.UnitVector().Draw(t, "center"),
+				FalseEasting:  rapid.Float64Range(-1e6, 1e6).Draw(t, "falseEasting"),
+				FalseNorthing: rapid.Float64Range(-1e6, 1e6).Draw(t, "falseNorthing"),
+			}
+			v := rapidgen.UnitVector().Draw(t, "v")
+			if v.Dot(p.Center) < 0.01 {
+				// skip n-vectors near the edge of the visible hemisphere, where
+				// small errors in the projected coordinates are magnified
+				t.Skip()
+			}
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-11); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error outside the projection", func(t *testing.T) {
+		p := Orthographic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+
+		if _, err := p.Inverse(ProjectedCoordinates{0, 1.1 * 6371e3}, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_Orthographic_Distortion(t *testing.T) {
+	t.Run("it foreshortens scale away from the center", func(t *testing.T) {
+		p := Orthographic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(60)},
+			ZAxisNorth,
+		)
+
+		got, err := p.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, 1, 1e-15); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 0.5, 1e-15); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 1e-15); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it returns an error on the far side", func(t *testing.T) {
+		p := Orthographic{
+			Radius: 6371e3,
+			Center: FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth),
+		}
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(120)},
+			ZAxisNorth,
+		)
+
+		if _, err := p.Distortion(v, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package nvector
+
+import (
+	"fmt"
+	"math"
+)
+
+// PolarStereographic is an ellipsoidal polar stereographic projection, centered
+// on one of the poles.
+//
+// The projection is defined by the scale factor at the pole (EPSG method 9810,
+// variant A). Use PolarStereographicB to define the projection by a standard
+// parallel instead.
+//
+// See: EPSG Guidance Note 7-2, Section 3.5.2
+type PolarStereographic struct {
+	// Ellipsoid is the reference ellipsoid.
+	Ellipsoid Ellipsoid
+	// Hemisphere is the hemisphere of the pole at the center of the projection.
+	Hemisphere Hemisphere
+	// CentralMeridian is the longitude in radians that projects to a straight
+	// line running from the pole towards the south in the northern hemisphere,
+	// or towards the north in the southern hemisphere.
+	CentralMeridian float64
+	// ScaleFactor is the scale factor at the pole.
+	ScaleFactor float64
+	// FalseEasting and FalseNorthing are the projected coordinates of the pole,
+	// in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// PolarStereographicB returns a polar stereographic projection defined by the
+// latitude of a standard parallel, on which the scale factor is 1 (EPSG method
+// 9829, variant B).
+//
+// The projection is centered on the pole in the same hemisphere as the
+// standard parallel. standardParallel and centralMeridian are given in radians,
+// and falseEasting and falseNorthing are the projected coordinates of the pole,
+// in meters.
+func PolarStereographicB(
+	e Ellipsoid,
+	standardParallel, centralMeridian float64,
+	falseEasting, falseNorthing float64,
+) PolarStereographic {
+	p := PolarStereographic{
+		Ellipsoid:       e,
+		Hemisphere:      NorthernHemisphere,
+		CentralMeridian: centralMeridian,
+		ScaleFactor:     1,
+		FalseEasting:    falseEasting,
+		FalseNorthing:   falseNorthing,
+	}
+	if standardParallel < 0 {
+		p.Hemisphere = SouthernHemisphere
+	}
+
+	if lat := math.Abs(standardParallel); lat < math.Pi/2 {
+		p.ScaleFactor = parallelM(lat, e) * polarStereographicC(e) /
+			(2 * conformalT(lat, e))
+	}
+
+	return p
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the n-vector is at the pole opposite the center of
+// the projection, which projects to infinity.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p PolarStereographic) Forward(
+	v Vector,
+	f Matrix,
+) (ProjectedCoordinates, error) {
+	rho, theta, _, err := p.polar(v, f)
+	if err != nil {
+		return ProjectedCoordinates{}, err
+	}
+
+	return ProjectedCoordinates{
+		p.FalseEasting + rho*math.Sin(theta),
+		p.FalseNorthing - p.sign()*rho*math.Cos(theta),
+	}, nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p PolarStereographic) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	s := p.sign()
+	x := c.Easting - p.FalseEasting
+	y := c.Northing - p.FalseNorthing
+
+	t := math.Hypot(x, y) * polarStereographicC(p.Ellipsoid) /
+		(2 * p.Ellipsoid.SemiMajorAxis * p.ScaleFactor)
+	lat := conformalTInverse(t, p.Ellipsoid)
+
+	return FromGeodeticCoordinates(
+		GeodeticCoordinates{
+			s * lat,
+			p.CentralMeridian + math.Atan2(x, -s*y),
+		},
+		f,
+	), nil
+}
+
+// Distortion returns the point scale factor and meridian convergence of the
+// projection at an n-vector.
+//
+// An error is returned if the n-vector is at the pole opposite the center of
+// the projection.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p PolarStereographic) Distortion(v Vector, f Matrix) (Distortion, error) {
+	rho, theta, lat, err := p.polar(v, f)
+	if err != nil {
+		return Distortion{}, err
+	}
+
+	// At the pole, the scale factor is the limit as the latitude tends to 90°
+	k := p.ScaleFactor
+	if lat < math.Pi/2 {
+		k = rho / (p.Ellipsoid.SemiMajorAxis * parallelM(lat, p.Ellipsoid))
+	}
+
+	return Distortion{k, k, p.sign() * theta}, nil
+}
+
+// polar returns the polar coordinates of an n-vector on the projection, and its
+// latitude reflected into the northern hemisphere if the projection is centered
+// on the south pole.
+func (p PolarStereographic) polar(
+	v Vector,
+	f Matrix,
+) (rho, theta, lat float64, err error) {
+	c := ToGeodeticCoordinates(v, f)
+	lat = p.sign() * c.Latitude
+
+	if lat == -math.Pi/2 {
+		return 0, 0, 0, fmt.Errorf(
+			"latitude %v° is at the pole opposite the center of the projection",
+			Degrees(c.Latitude),
+		)
+	}
+
+	rho = 2 * p.Ellipsoid.SemiMajorAxis * p.ScaleFactor *
+		conformalT(lat, p.Ellipsoid) / polarStereographicC(p.Ellipsoid)
+	theta = math.Remainder(c.Longitude-p.CentralMeridian, 2*math.Pi)
+
+	return rho, theta, lat, nil
+}
+
+// sign returns 1 if the projection is centered on the north pole, and -1 if it
+// is centered on the south pole.
+func (p PolarStereographic) sign() float64 {
+	if p.Hemisphere == SouthernHemisphere {
+		return -1
+	}
+
+	return 1
+}
+
+// polarStereographicC returns the constant sqrt((1+e)^(1+e) * (1-e)^(1-e)),
+// where e is the eccentricity of the ellipsoid.
+func polarStereographicC(e Ellipsoid) float64 {
+	ecc := e.Eccentricity()
+
+	return math.Sqrt(math.Pow(1+ecc, 1+ecc) * math.Pow(1-ecc, 1-ecc))
+}
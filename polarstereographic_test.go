@@ -0,0 +1,217 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+// upsNorth is the WGS84 / UPS North projection, which uses variant A.
+//
+// See: EPSG Guidance Note 7-2, Section 3.5.2.1
+var upsNorth = PolarStereographic{
+	Ellipsoid:     WGS84,
+	Hemisphere:    NorthernHemisphere,
+	ScaleFactor:   0.994,
+	FalseEasting:  2000000,
+	FalseNorthing: 2000000,
+}
+
+// australianAntarctic is the WGS84 / Australian Antarctic Polar Stereographic
+// projection, which uses variant B.
+//
+// See: EPSG Guidance Note 7-2, Section 3.5.2.2
+var australianAntarctic = PolarStereographicB(
+	WGS84,
+	Radians(-71),
+	Radians(70),
+	6000000,
+	6000000,
+)
+
+func Test_PolarStereographic_Forward(t *testing.T) {
+	tests := map[string]struct {
+		p                 PolarStereographic
+		lat, lon          float64
+		easting, northing float64
+	}{
+		"variant A": {upsNorth, 73, 44, 3320416.75, 632668.43},
+		"variant B": {australianAntarctic, -75, 120, 7255380.79, 7053389.56},
+	}
+
+	for name, tt := range tests {
+		t.Run("it matches the EPSG worked example for "+name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			got, err := tt.p.Forward(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToFloat64(got.Easting, tt.easting, 1e-2); !eq {
+				equality.ReportInequality(t, "Easting", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Northing, tt.northing, 1e-2); !eq {
+				equality.ReportInequality(t, "Northing", ineq)
+			}
+		})
+	}
+
+	t.Run("it projects the pole to the false origin", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(-90), 0},
+			ZAxisNorth,
+		)
+
+		got, err := australianAntarctic.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := (ProjectedCoordinates{6000000, 6000000}); got != want {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it returns an error at the opposite pole", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(-90), 0},
+			ZAxisNorth,
+		)
+
+		if _, err := upsNorth.Forward(v, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_PolarStereographic_Inverse(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v, err := australianAntarctic.Inverse(
+			ProjectedCoordinates{7255380.79, 7053389.56},
+			ZAxisNorth,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ToGeodeticCoordinates(v, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToRadians(got.Latitude, Radians(-75), 5e-9); !eq {
+			equality.ReportInequality(t, "Latitude", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Longitude, Radians(120), 5e-9); !eq {
+			equality.ReportInequality(t, "Longitude", ineq)
+		}
+	})
+
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			h := rapid.SampledFrom([]Hemisphere{NorthernHemisphere, SouthernHemisphere}).
+				Draw(t, "hemisphere")
+			p := PolarStereographic{
+				Ellipsoid:       rapidgen.Ellipsoid().Draw(t, "ellipsoid"),
+				Hemisphere:      h,
+				CentralMeridian: Radians(rapid.Float64Range(-180, 180).Draw(t, "centralMeridian")),
+				ScaleFactor:     rapid.Float64Range(0.9, 1.1).Draw(t, "scaleFactor"),
+				FalseEasting:    rapid.Float64Range(-1e7, 1e7).Draw(t, "falseEasting"),
+				FalseNorthing:   rapid.Float64Range(-1e7, 1e7).Draw(t, "falseNorthing"),
+			}
+			lat := Radians(rapid.Float64Range(0, 90).Draw(t, "latitude"))
+			if h == SouthernHemisphere {
+				lat = -lat
+			}
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{
+					Latitude:  lat,
+					Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude")),
+				},
+				f,
+			)
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_PolarStereographic_Distortion(t *testing.T) {
+	t.Run("it has the scale factor at the pole", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(90), 0},
+			ZAxisNorth,
+		)
+
+		got, err := upsNorth.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 0.994, 1e-15); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+	})
+
+	t.Run("it has a scale factor of 1 on the standard parallel", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(-71), Radians(10)},
+			ZAxisNorth,
+		)
+
+		got, err := australianAntarctic.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, 1, 1e-12); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 1, 1e-12); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, Radians(60), 1e-12); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it matches the spherical formulas", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := PolarStereographic{Ellipsoid: Sphere(6371e3), ScaleFactor: 1}
+			lat := Radians(rapid.Float64Range(-89, 89).Draw(t, "latitude"))
+			lon := Radians(rapid.Float64Range(-179, 179).Draw(t, "longitude"))
+			v := FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth)
+
+			got, err := p.Distortion(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Equation (21-4) in Snyder (1987)
+			wantK := 2 / (1 + math.Sin(lat))
+
+			if eq, ineq := equality.EqualToFloat64(got.ParallelScale/wantK, 1, 1e-12); !eq {
+				equality.ReportInequality(t, "ParallelScale", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(got.Convergence, lon, 1e-12); !eq {
+				equality.ReportInequality(t, "Convergence", ineq)
+			}
+		})
+	})
+}
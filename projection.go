@@ -0,0 +1,96 @@
+package nvector
+
+import (
+	"math"
+)
+
+// ProjectedCoordinates is a position on a map projection.
+//
+// Easting and Northing are given in meters.
+type ProjectedCoordinates struct {
+	Easting, Northing float64
+}
+
+// Distortion describes the distortion introduced by a map projection at a
+// position.
+//
+// For conformal projections, MeridianScale and ParallelScale are equal, and are
+// known as the point scale factor.
+type Distortion struct {
+	// MeridianScale is the scale factor along the meridian.
+	MeridianScale float64
+	// ParallelScale is the scale factor along the parallel.
+	ParallelScale float64
+	// Convergence is the meridian convergence in radians. This is the bearing of
+	// grid north, measured clockwise from true north.
+	Convergence float64
+}
+
+// Projection is a map projection.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+type Projection interface {
+	// Forward converts an n-vector to projected coordinates.
+	Forward(v Vector, f Matrix) (ProjectedCoordinates, error)
+	// Inverse converts projected coordinates to an n-vector.
+	Inverse(c ProjectedCoordinates, f Matrix) (Vector, error)
+	// Distortion returns the distortion of the projection at an n-vector.
+	Distortion(v Vector, f Matrix) (Distortion, error)
+}
+
+// azimuthalDistortion returns the distortion of an azimuthal projection at a
+// position, given the scale factors along and perpendicular to the direction
+// away from the center, the grid azimuth in radians of that direction, and its
+// true azimuth in radians at the position.
+func azimuthalDistortion(
+	radial, azimuthal, gridAzimuth, azimuth float64,
+) Distortion {
+	return jacobianDistortion(
+		azimuthalJacobian(radial, azimuthal, gridAzimuth, azimuth),
+	)
+}
+
+// azimuthalJacobian returns the projected displacements of unit displacements
+// to the north and east of a position, for an azimuthal projection with the
+// scale factors and azimuths described by azimuthalDistortion.
+func azimuthalJacobian(
+	radial, azimuthal, gridAzimuth, azimuth float64,
+) (north, east ProjectedCoordinates) {
+	sinAz, cosAz := math.Sincos(azimuth)
+	sinGrid, cosGrid := math.Sincos(gridAzimuth)
+
+	// The projected displacements away from the center, and 90° clockwise from
+	// that direction:
+	away := ProjectedCoordinates{radial * sinGrid, radial * cosGrid}
+	across := ProjectedCoordinates{azimuthal * cosGrid, -azimuthal * sinGrid}
+
+	north = ProjectedCoordinates{
+		cosAz*away.Easting - sinAz*across.Easting,
+		cosAz*away.Northing - sinAz*across.Northing,
+	}
+	east = ProjectedCoordinates{
+		sinAz*away.Easting + cosAz*across.Easting,
+		sinAz*away.Northing + cosAz*across.Northing,
+	}
+
+	return north, east
+}
+
+// jacobianDistortion returns the distortion of a projection at a position,
+// given the projected displacements of unit displacements to the north and
+// east of the position.
+func jacobianDistortion(north, east ProjectedCoordinates) Distortion {
+	return Distortion{
+		math.Hypot(north.Easting, north.Northing),
+		math.Hypot(east.Easting, east.Northing),
+		-math.Atan2(north.Easting, north.Northing),
+	}
+}
+
+// awayFrom returns the azimuth in radians at an n-vector of the great circle
+// direction away from another n-vector.
+func awayFrom(from, v Vector, f Matrix) float64 {
+	d := v.Scale(from.Dot(v)).Sub(from).Transform(ToRotationMatrix(v, f).Transpose())
+
+	return math.Atan2(d.Y, d.X)
+}
@@ -0,0 +1,126 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"pgregory.net/rapid"
+)
+
+func Test_Projection_Distortion(t *testing.T) {
+	center := func(lat, lon float64) Vector {
+		return FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(lat), Radians(lon)},
+			ZAxisNorth,
+		)
+	}
+
+	tests := map[string]struct {
+		p        Projection
+		e        Ellipsoid
+		lat, lon float64
+	}{
+		"transverse Mercator": {
+			osgbNationalGrid, osgbNationalGrid.Ellipsoid, 54, -2,
+		},
+		"Web Mercator": {
+			WebMercator{}, Sphere(6378137), 30, 0,
+		},
+		"Lambert conformal conic": {
+			texasSouthCentral, clarke1866, 30, -99,
+		},
+		"polar stereographic": {
+			australianAntarctic, WGS84, -75, 120,
+		},
+		"azimuthal equidistant on a sphere": {
+			AzimuthalEquidistant{Ellipsoid: Sphere(6371e3), Center: center(40, 20)},
+			Sphere(6371e3), 30, 40,
+		},
+		"azimuthal equidistant on an ellipsoid": {
+			AzimuthalEquidistant{Ellipsoid: WGS84, Center: center(40, 20)},
+			WGS84, 30, 40,
+		},
+		"Lambert azimuthal equal-area on a sphere": {
+			LambertAzimuthalEqualArea{Ellipsoid: Sphere(6371e3), Center: center(40, 20)},
+			Sphere(6371e3), 30, 40,
+		},
+		"Lambert azimuthal equal-area on an ellipsoid": {
+			etrs89LAEA, GRS80, 50, 5,
+		},
+		"orthographic": {
+			Orthographic{Radius: 6371e3, Center: center(10, -60)},
+			Sphere(6371e3), 25, -45,
+		},
+		"gnomonic": {
+			Gnomonic{Radius: 6371e3, Center: center(-30, 150)},
+			Sphere(6371e3), -40, 160,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Run("it matches the numerical derivatives of Forward", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					lat := Radians(tt.lat + rapid.Float64Range(-10, 10).Draw(t, "latitude"))
+					lon := Radians(tt.lon + rapid.Float64Range(-10, 10).Draw(t, "longitude"))
+					forward := func(lat, lon float64) ProjectedCoordinates {
+						c, err := tt.p.Forward(
+							FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth),
+							ZAxisNorth,
+						)
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						return c
+					}
+
+					// Central differences along the meridian and the parallel
+					const step = 1e-6
+					n0, n1 := forward(lat-step, lon), forward(lat+step, lon)
+					e0, e1 := forward(lat, lon-step), forward(lat, lon+step)
+					dNorth := math.Hypot(n1.Easting-n0.Easting, n1.Northing-n0.Northing)
+					dEast := math.Hypot(e1.Easting-e0.Easting, e1.Northing-e0.Northing)
+
+					// Radii of curvature in the meridian and the prime vertical
+					e2 := tt.e.Flattening * (2 - tt.e.Flattening)
+					w := math.Sqrt(1 - e2*math.Pow(math.Sin(lat), 2))
+					m := tt.e.SemiMajorAxis * (1 - e2) / (w * w * w)
+					n := tt.e.SemiMajorAxis / w
+
+					got, err := tt.p.Distortion(
+						FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth),
+						ZAxisNorth,
+					)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if eq, ineq := equality.EqualToFloat64(
+						got.MeridianScale,
+						dNorth/(2*step*m),
+						1e-6,
+					); !eq {
+						equality.ReportInequality(t, "MeridianScale", ineq)
+					}
+					if eq, ineq := equality.EqualToFloat64(
+						got.ParallelScale,
+						dEast/(2*step*n*math.Cos(lat)),
+						1e-6,
+					); !eq {
+						equality.ReportInequality(t, "ParallelScale", ineq)
+					}
+					if eq, ineq := equality.EqualToRadians(
+						got.Convergence,
+						-math.Atan2(n1.Easting-n0.Easting, n1.Northing-n0.Northing),
+						1e-6,
+					); !eq {
+						equality.ReportInequality(t, "Convergence", ineq)
+					}
+				})
+			})
+		})
+	}
+}
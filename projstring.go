@@ -0,0 +1,572 @@
+package nvector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// projIgnoredParams are PROJ parameters that are accepted but ignored, because
+// they don't affect the projection.
+var projIgnoredParams = []string{"no_defs", "type", "wktext"}
+
+// projEllipsoids are the ellipsoids that can be selected with +ellps.
+var projEllipsoids = map[string]Ellipsoid{
+	"airy":      Airy1830,
+	"aust_SA":   AustralianNational,
+	"bessel":    Bessel1841,
+	"clrk66":    Clarke1866,
+	"clrk80":    Clarke1880,
+	"clrk80ign": Clarke1880IGN,
+	"evrst30":   Everest1830,
+	"GRS67":     GRS67,
+	"GRS80":     GRS80,
+	"helmert":   Helmert1906,
+	"intl":      International1924,
+	"krass":     Krassovsky1940,
+	"mod_airy":  AiryModified,
+	"WGS72":     WGS72,
+	"WGS84":     WGS84,
+}
+
+// projDatums are the datums that can be selected with +datum.
+var projDatums = map[string]Datum{
+	"ED50":    DatumED50,
+	"NAD27":   DatumNAD27,
+	"NAD83":   DatumNAD83,
+	"OSGB36":  DatumOSGB36,
+	"potsdam": DatumPotsdam,
+	"WGS84":   DatumWGS84,
+}
+
+// ParseProjString parses a projection from a PROJ string, e.g. "+proj=tmerc
+// +lon_0=173 +k_0=0.9996 +x_0=1600000 +y_0=10000000 +ellps=GRS80".
+//
+// The following projections are supported:
+//
+//   - tmerc: TransverseMercator, with +lat_0, +lon_0, +k_0 (or +k), +x_0, and
+//     +y_0.
+//   - utm: TransverseMercator for a UTM zone, with +zone and +south.
+//   - lcc: LambertConformalConic, with +lat_1, +lat_2, +lat_0, +lon_0, +k_0 (or
+//     +k), +x_0, and +y_0. If +lat_2 is omitted, it's equal to +lat_1.
+//   - stere: PolarStereographic, with +lat_0 (which must be 90 or -90),
+//     +lon_0, +x_0, +y_0, and either +lat_ts or +k_0 (or +k).
+//   - ups: PolarStereographic for a UPS hemisphere, with +south.
+//   - webmerc: WebMercator.
+//   - aeqd: AzimuthalEquidistant, with +lat_0, +lon_0, +x_0, and +y_0.
+//   - laea: LambertAzimuthalEqualArea, with +lat_0, +lon_0, +x_0, and +y_0.
+//   - gnom: Gnomonic, with +lat_0, +lon_0, +x_0, and +y_0. The ellipsoid must
+//     be a sphere.
+//   - ortho: Orthographic, with +lat_0, +lon_0, +x_0, and +y_0. The ellipsoid
+//     must be a sphere.
+//
+// The ellipsoid is given by +R for a sphere, +a with one of +rf, +f, or +b,
+// +ellps (airy, aust_SA, bessel, clrk66, clrk80, clrk80ign, evrst30, GRS67,
+// GRS80, helmert, intl, krass, mod_airy, WGS72, or WGS84), or the ellipsoid of
+// +datum (ED50, NAD27, NAD83, OSGB36, potsdam, or WGS84). If both an ellipsoid
+// and a datum are given, they must be consistent. If neither is given, GRS80
+// is used, as in PROJ. Angles are given in degrees, and the only supported
+// unit is the meter (+units=m). The +no_defs, +type, and +wktext parameters
+// are ignored. An error is returned for any other parameter, including the
+// datum shifts +towgs84 and +nadgrids, which are not applied.
+//
+// The centers of the azimuthal projections are decomposed in f.
+//
+// See: https://proj.org/en/stable/usage/projections.html
+func ParseProjString(s string, f Matrix) (Projection, error) {
+	params, err := parseProjParams(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := params.projection(f)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range params.keys {
+		if !params.used[k] && !slices.Contains(projIgnoredParams, k) {
+			return nil, fmt.Errorf("unsupported PROJ parameter +%s", k)
+		}
+	}
+
+	return p, nil
+}
+
+// projParams are the parameters of a PROJ string.
+type projParams struct {
+	keys   []string
+	values map[string]string
+	used   map[string]bool
+}
+
+// parseProjParams splits a PROJ string into its parameters.
+func parseProjParams(s string) (projParams, error) {
+	params := projParams{
+		values: map[string]string{},
+		used:   map[string]bool{},
+	}
+
+	for _, field := range strings.Fields(s) {
+		k, v, _ := strings.Cut(field, "=")
+		if len(k) < 2 || k[0] != '+' {
+			return projParams{}, fmt.Errorf("invalid PROJ parameter %q", field)
+		}
+
+		k = k[1:]
+		if _, ok := params.values[k]; ok {
+			return projParams{}, fmt.Errorf("duplicate PROJ parameter +%s", k)
+		}
+
+		params.keys = append(params.keys, k)
+		params.values[k] = v
+	}
+
+	return params, nil
+}
+
+// projection returns the projection described by the parameters.
+func (params projParams) projection(f Matrix) (Projection, error) {
+	name, ok := params.string("proj")
+	if !ok {
+		return nil, errors.New("missing PROJ parameter +proj")
+	}
+
+	if units, ok := params.string("units"); ok && units != "m" {
+		return nil, fmt.Errorf("unsupported PROJ units %q", units)
+	}
+
+	e, err := params.ellipsoid()
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "tmerc":
+		return params.transverseMercator(e)
+	case "utm":
+		return params.utm(e)
+	case "lcc":
+		return params.lambertConformalConic(e)
+	case "stere":
+		return params.polarStereographic(e)
+	case "ups":
+		h := NorthernHemisphere
+		if params.has("south") {
+			h = SouthernHemisphere
+		}
+
+		return upsProjection(e, h), nil
+	case "webmerc":
+		return WebMercator{}, nil
+	case "aeqd":
+		return params.azimuthalEquidistant(e, f)
+	case "laea":
+		return params.lambertAzimuthalEqualArea(e, f)
+	case "gnom":
+		return params.gnomonic(e, f)
+	case "ortho":
+		return params.orthographic(e, f)
+	}
+
+	return nil, fmt.Errorf("unsupported PROJ projection %q", name)
+}
+
+// ellipsoid returns the ellipsoid described by the parameters.
+func (params projParams) ellipsoid() (Ellipsoid, error) {
+	datum, hasDatum := params.string("datum")
+	var de Ellipsoid
+	if hasDatum {
+		d, ok := projDatums[datum]
+		if !ok {
+			return Ellipsoid{}, fmt.Errorf("unsupported PROJ datum %q", datum)
+		}
+		de = d.Ellipsoid
+	}
+
+	e, ok, err := params.explicitEllipsoid()
+	if err != nil {
+		return Ellipsoid{}, err
+	}
+
+	switch {
+	case ok && hasDatum && !sameEllipsoid(e, de):
+		return Ellipsoid{}, fmt.Errorf(
+			"PROJ ellipsoid is inconsistent with datum %q",
+			datum,
+		)
+	case ok:
+		return e, nil
+	case hasDatum:
+		return de, nil
+	}
+
+	return GRS80, nil
+}
+
+// explicitEllipsoid returns the ellipsoid described by the +R, +a, or +ellps
+// parameters, and whether one was given.
+func (params projParams) explicitEllipsoid() (Ellipsoid, bool, error) {
+	if params.has("R") {
+		r, err := params.float("R", 0)
+		if err != nil {
+			return Ellipsoid{}, false, err
+		}
+
+		return Sphere(r), true, nil
+	}
+
+	if params.has("a") {
+		a, err := params.float("a", 0)
+		if err != nil {
+			return Ellipsoid{}, false, err
+		}
+
+		switch {
+		case params.has("rf"):
+			rf, err := params.float("rf", 0)
+			if err != nil {
+				return Ellipsoid{}, false, err
+			}
+
+			e, err := EllipsoidFromInverseFlattening(a, rf)
+			if err != nil {
+				return Ellipsoid{}, false, err
+			}
+
+			return e, true, nil
+		case params.has("f"):
+			fl, err := params.float("f", 0)
+			if err != nil {
+				return Ellipsoid{}, false, err
+			}
+
+			return Ellipsoid{a, a * (1 - fl), fl}, true, nil
+		case params.has("b"):
+			b, err := params.float("b", 0)
+			if err != nil {
+				return Ellipsoid{}, false, err
+			}
+
+			return Ellipsoid{a, b, (a - b) / a}, true, nil
+		}
+
+		return Sphere(a), true, nil
+	}
+
+	if name, ok := params.string("ellps"); ok {
+		e, ok := projEllipsoids[name]
+		if !ok {
+			return Ellipsoid{}, false, fmt.Errorf("unsupported PROJ ellipsoid %q", name)
+		}
+
+		return e, true, nil
+	}
+
+	return Ellipsoid{}, false, nil
+}
+
+// sameEllipsoid returns true if two ellipsoids have the same semi-major axis
+// and flattening, allowing for rounding in their construction.
+func sameEllipsoid(a, b Ellipsoid) bool {
+	return math.Abs(a.SemiMajorAxis-b.SemiMajorAxis) <= 1e-12*a.SemiMajorAxis &&
+		math.Abs(a.Flattening-b.Flattening) <= 1e-12
+}
+
+// transverseMercator returns the tmerc projection described by the parameters.
+func (params projParams) transverseMercator(e Ellipsoid) (Projection, error) {
+	var err error
+	p := TransverseMercator{Ellipsoid: e}
+
+	if p.Origin, err = params.origin(); err != nil {
+		return nil, err
+	}
+	if p.ScaleFactor, err = params.scaleFactor(); err != nil {
+		return nil, err
+	}
+	if p.FalseEasting, p.FalseNorthing, err = params.falseOrigin(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// utm returns the utm projection described by the parameters.
+func (params projParams) utm(e Ellipsoid) (Projection, error) {
+	zone, err := params.float("zone", 0)
+	if err != nil {
+		return nil, err
+	}
+	if zone != math.Trunc(zone) {
+		return nil, fmt.Errorf("invalid UTM zone %v", zone)
+	}
+	if err := checkUTMZone(int(zone)); err != nil {
+		return nil, err
+	}
+
+	h := NorthernHemisphere
+	if params.has("south") {
+		h = SouthernHemisphere
+	}
+
+	return utmProjection(e, int(zone), h), nil
+}
+
+// lambertConformalConic returns the lcc projection described by the
+// parameters.
+func (params projParams) lambertConformalConic(e Ellipsoid) (Projection, error) {
+	var err error
+	p := LambertConformalConic{Ellipsoid: e}
+
+	if !params.has("lat_1") {
+		return nil, errors.New("missing PROJ parameter +lat_1")
+	}
+	if p.StandardParallel1, err = params.angle("lat_1", 0); err != nil {
+		return nil, err
+	}
+	p.StandardParallel2 = p.StandardParallel1
+	if params.has("lat_2") {
+		if p.StandardParallel2, err = params.angle("lat_2", 0); err != nil {
+			return nil, err
+		}
+	}
+	if p.Origin, err = params.origin(); err != nil {
+		return nil, err
+	}
+	if p.ScaleFactor, err = params.scaleFactor(); err != nil {
+		return nil, err
+	}
+	if p.FalseEasting, p.FalseNorthing, err = params.falseOrigin(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// polarStereographic returns the stere projection described by the parameters.
+func (params projParams) polarStereographic(e Ellipsoid) (Projection, error) {
+	lat0, err := params.float("lat_0", 0)
+	if err != nil {
+		return nil, err
+	}
+	lon0, err := params.angle("lon_0", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	h := NorthernHemisphere
+	switch lat0 {
+	case 90:
+	case -90:
+		h = SouthernHemisphere
+	default:
+		return nil, fmt.Errorf(
+			"unsupported PROJ stereographic latitude of origin %v",
+			lat0,
+		)
+	}
+
+	falseEasting, falseNorthing, err := params.falseOrigin()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.has("lat_ts") {
+		lat, err := params.angle("lat_ts", 0)
+		if err != nil {
+			return nil, err
+		}
+		if (lat < 0) != (h == SouthernHemisphere) {
+			return nil, fmt.Errorf(
+				"PROJ standard parallel %v is in the wrong hemisphere",
+				Degrees(lat),
+			)
+		}
+
+		return PolarStereographicB(
+			e,
+			lat,
+			lon0,
+			falseEasting,
+			falseNorthing,
+		), nil
+	}
+
+	k, err := params.scaleFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	return PolarStereographic{
+		Ellipsoid:       e,
+		Hemisphere:      h,
+		CentralMeridian: lon0,
+		ScaleFactor:     k,
+		FalseEasting:    falseEasting,
+		FalseNorthing:   falseNorthing,
+	}, nil
+}
+
+// azimuthalEquidistant returns the aeqd projection described by the
+// parameters.
+func (params projParams) azimuthalEquidistant(
+	e Ellipsoid,
+	f Matrix,
+) (Projection, error) {
+	p := AzimuthalEquidistant{Ellipsoid: e}
+
+	origin, err := params.origin()
+	if err != nil {
+		return nil, err
+	}
+	p.Center = FromGeodeticCoordinates(origin, f)
+	if p.FalseEasting, p.FalseNorthing, err = params.falseOrigin(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// lambertAzimuthalEqualArea returns the laea projection described by the
+// parameters.
+func (params projParams) lambertAzimuthalEqualArea(
+	e Ellipsoid,
+	f Matrix,
+) (Projection, error) {
+	p := LambertAzimuthalEqualArea{Ellipsoid: e}
+
+	origin, err := params.origin()
+	if err != nil {
+		return nil, err
+	}
+	p.Center = FromGeodeticCoordinates(origin, f)
+	if p.FalseEasting, p.FalseNorthing, err = params.falseOrigin(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// gnomonic returns the gnom projection described by the parameters.
+func (params projParams) gnomonic(e Ellipsoid, f Matrix) (Projection, error) {
+	if e.Flattening != 0 {
+		return nil, errors.New("PROJ gnomonic projection requires a sphere")
+	}
+
+	p := Gnomonic{Radius: e.SemiMajorAxis}
+
+	origin, err := params.origin()
+	if err != nil {
+		return nil, err
+	}
+	p.Center = FromGeodeticCoordinates(origin, f)
+	if p.FalseEasting, p.FalseNorthing, err = params.falseOrigin(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// orthographic returns the ortho projection described by the parameters.
+func (params projParams) orthographic(e Ellipsoid, f Matrix) (Projection, error) {
+	if e.Flattening != 0 {
+		return nil, errors.New("PROJ orthographic projection requires a sphere")
+	}
+
+	p := Orthographic{Radius: e.SemiMajorAxis}
+
+	origin, err := params.origin()
+	if err != nil {
+		return nil, err
+	}
+	p.Center = FromGeodeticCoordinates(origin, f)
+	if p.FalseEasting, p.FalseNorthing, err = params.falseOrigin(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// origin returns the origin given by +lat_0 and +lon_0.
+func (params projParams) origin() (GeodeticCoordinates, error) {
+	lat, err := params.angle("lat_0", 0)
+	if err != nil {
+		return GeodeticCoordinates{}, err
+	}
+	lon, err := params.angle("lon_0", 0)
+	if err != nil {
+		return GeodeticCoordinates{}, err
+	}
+
+	return GeodeticCoordinates{lat, lon}, nil
+}
+
+// scaleFactor returns the scale factor given by +k_0 or +k.
+func (params projParams) scaleFactor() (float64, error) {
+	if params.has("k_0") {
+		return params.float("k_0", 1)
+	}
+
+	return params.float("k", 1)
+}
+
+// falseOrigin returns the false easting and northing given by +x_0 and +y_0.
+func (params projParams) falseOrigin() (x, y float64, err error) {
+	if x, err = params.float("x_0", 0); err != nil {
+		return 0, 0, err
+	}
+	if y, err = params.float("y_0", 0); err != nil {
+		return 0, 0, err
+	}
+
+	return x, y, nil
+}
+
+// has returns true if a parameter is present, and marks it as used.
+func (params projParams) has(k string) bool {
+	_, ok := params.values[k]
+	if ok {
+		params.used[k] = true
+	}
+
+	return ok
+}
+
+// string returns the value of a parameter.
+func (params projParams) string(k string) (string, bool) {
+	if !params.has(k) {
+		return "", false
+	}
+
+	return params.values[k], true
+}
+
+// float returns the numeric value of a parameter, or a default value if it's
+// not present.
+func (params projParams) float(k string, def float64) (float64, error) {
+	s, ok := params.string(k)
+	if !ok {
+		return def, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid PROJ parameter +%s=%s", k, s)
+	}
+
+	return v, nil
+}
+
+// angle returns the value in radians of a parameter given in degrees, or a
+// default value in degrees if it's not present.
+func (params projParams) angle(k string, def float64) (float64, error) {
+	v, err := params.float(k, def)
+	if err != nil {
+		return 0, err
+	}
+
+	return Radians(v), nil
+}
@@ -0,0 +1,216 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+)
+
+func Test_ParseProjString(t *testing.T) {
+	t.Run("it parses supported projections", func(t *testing.T) {
+		a, b := 6378137.0, 6356752.314245179
+
+		tests := map[string]struct {
+			s    string
+			want Projection
+		}{
+			"transverse Mercator": {
+				"+proj=tmerc +lat_0=49 +lon_0=-2 +k=0.9996012717 +x_0=400000 " +
+					"+y_0=-100000 +a=6377563.396 +rf=299.3249646 +units=m +no_defs",
+				osgbNationalGrid,
+			},
+			"transverse Mercator with a named ellipsoid": {
+				"+proj=tmerc +lat_0=49 +lon_0=-2 +k=0.9996012717 +x_0=400000 " +
+					"+y_0=-100000 +ellps=airy",
+				osgbNationalGrid,
+			},
+			"UTM": {
+				"+proj=utm +zone=56 +south +ellps=WGS84",
+				TransverseMercator{
+					Ellipsoid:     WGS84,
+					Origin:        GeodeticCoordinates{0, Radians(153)},
+					ScaleFactor:   0.9996,
+					FalseEasting:  500000,
+					FalseNorthing: 10000000,
+				},
+			},
+			"Lambert conformal conic": {
+				"+proj=lcc +lat_1=28.3833333333333 +lat_2=30.2833333333333 " +
+					"+lat_0=27.8333333333333 +lon_0=-99 +x_0=600000 +y_0=4000000 " +
+					"+ellps=GRS80",
+				LambertConformalConic{
+					Ellipsoid:         GRS80,
+					Origin:            GeodeticCoordinates{Radians(27.8333333333333), Radians(-99)},
+					StandardParallel1: Radians(28.3833333333333),
+					StandardParallel2: Radians(30.2833333333333),
+					ScaleFactor:       1,
+					FalseEasting:      600000,
+					FalseNorthing:     4000000,
+				},
+			},
+			"Lambert conformal conic with one standard parallel": {
+				"+proj=lcc +lat_1=18 +lat_0=18 +lon_0=-77 +k_0=1 +x_0=250000 " +
+					"+y_0=150000 +R=6371000",
+				LambertConformalConic{
+					Ellipsoid:         Sphere(6371000),
+					Origin:            GeodeticCoordinates{Radians(18), Radians(-77)},
+					StandardParallel1: Radians(18),
+					StandardParallel2: Radians(18),
+					ScaleFactor:       1,
+					FalseEasting:      250000,
+					FalseNorthing:     150000,
+				},
+			},
+			"polar stereographic with a scale factor": {
+				"+proj=stere +lat_0=90 +lon_0=0 +k=0.994 +x_0=2000000 +y_0=2000000 " +
+					"+datum=WGS84",
+				upsNorth,
+			},
+			"polar stereographic with a standard parallel": {
+				"+proj=stere +lat_0=-90 +lat_ts=-71 +lon_0=70 +x_0=6000000 " +
+					"+y_0=6000000 +ellps=WGS84",
+				australianAntarctic,
+			},
+			"UPS": {
+				"+proj=ups +south +ellps=WGS84",
+				PolarStereographic{
+					Ellipsoid:     WGS84,
+					Hemisphere:    SouthernHemisphere,
+					ScaleFactor:   0.994,
+					FalseEasting:  2000000,
+					FalseNorthing: 2000000,
+				},
+			},
+			"Web Mercator": {
+				"+proj=webmerc +datum=WGS84 +type=crs",
+				WebMercator{},
+			},
+			"azimuthal equidistant": {
+				"+proj=aeqd +lat_0=-35 +lon_0=150 +x_0=1000 +y_0=2000 +a=6378137 " +
+					"+b=6356752.314245179",
+				AzimuthalEquidistant{
+					Ellipsoid: Ellipsoid{a, b, (a - b) / a},
+					Center: FromGeodeticCoordinates(
+						GeodeticCoordinates{Radians(-35), Radians(150)},
+						ZAxisNorth,
+					),
+					FalseEasting:  1000,
+					FalseNorthing: 2000,
+				},
+			},
+			"gnomonic": {
+				"+proj=gnom +lat_0=90 +lon_0=0 +R=6371000",
+				Gnomonic{
+					Radius: 6371000,
+					Center: FromGeodeticCoordinates(
+						GeodeticCoordinates{Radians(90), 0},
+						ZAxisNorth,
+					),
+				},
+			},
+			"Lambert azimuthal equal-area": {
+				"+proj=laea +lat_0=52 +lon_0=10 +x_0=4321000 +y_0=3210000 +ellps=GRS80",
+				etrs89LAEA,
+			},
+			"orthographic": {
+				"+proj=ortho +lat_0=45 +lon_0=-90 +R=6371000",
+				Orthographic{
+					Radius: 6371000,
+					Center: FromGeodeticCoordinates(
+						GeodeticCoordinates{Radians(45), Radians(-90)},
+						ZAxisNorth,
+					),
+				},
+			},
+			"UTM with a consistent ellipsoid and datum": {
+				"+proj=utm +zone=33 +ellps=WGS84 +datum=WGS84 +units=m +no_defs",
+				TransverseMercator{
+					Ellipsoid:    WGS84,
+					Origin:       GeodeticCoordinates{0, Radians(15)},
+					ScaleFactor:  0.9996,
+					FalseEasting: 500000,
+				},
+			},
+			"NAD83 with GRS80": {
+				"+proj=utm +zone=18 +ellps=GRS80 +datum=NAD83",
+				TransverseMercator{
+					Ellipsoid:    GRS80,
+					Origin:       GeodeticCoordinates{0, Radians(-75)},
+					ScaleFactor:  0.9996,
+					FalseEasting: 500000,
+				},
+			},
+			"NAD83 datum": {
+				"+proj=utm +zone=18 +datum=NAD83",
+				TransverseMercator{
+					Ellipsoid:    GRS80,
+					Origin:       GeodeticCoordinates{0, Radians(-75)},
+					ScaleFactor:  0.9996,
+					FalseEasting: 500000,
+				},
+			},
+			"OSGB36 datum": {
+				"+proj=tmerc +lat_0=49 +lon_0=-2 +k=0.9996012717 +x_0=400000 " +
+					"+y_0=-100000 +datum=OSGB36",
+				osgbNationalGrid,
+			},
+			"default ellipsoid": {
+				"+proj=utm +zone=31",
+				TransverseMercator{
+					Ellipsoid:    GRS80,
+					Origin:       GeodeticCoordinates{0, Radians(3)},
+					ScaleFactor:  0.9996,
+					FalseEasting: 500000,
+				},
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				got, err := ParseProjString(tt.s, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got != tt.want {
+					t.Errorf("got %+v; want %+v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("it returns an error for invalid or unsupported PROJ strings", func(t *testing.T) {
+		tests := map[string]string{
+			"missing projection":         "+ellps=WGS84",
+			"unknown projection":         "+proj=robin +ellps=WGS84",
+			"unknown parameter":          "+proj=tmerc +lat_0=49 +foo=bar",
+			"duplicate parameter":        "+proj=tmerc +lat_0=49 +lat_0=50",
+			"missing plus":               "proj=tmerc",
+			"invalid number":             "+proj=tmerc +lat_0=north",
+			"unsupported units":          "+proj=tmerc +units=us-ft",
+			"unknown ellipsoid":          "+proj=tmerc +ellps=foo",
+			"invalid inverse flattening": "+proj=tmerc +a=6378137 +rf=0",
+			"unknown datum":              "+proj=tmerc +datum=carthage",
+			"inconsistent datum":         "+proj=utm +zone=33 +ellps=WGS84 +datum=OSGB36",
+			"inconsistent sphere":        "+proj=tmerc +R=6371000 +datum=WGS84",
+			"datum shift":                "+proj=utm +zone=33 +ellps=WGS72 +towgs84=0,0,4.5,0,0,0.554,0.2263",
+			"datum shift grid":           "+proj=utm +zone=18 +ellps=GRS80 +nadgrids=@null",
+			"missing UTM zone":           "+proj=utm +ellps=WGS84",
+			"invalid UTM zone":           "+proj=utm +zone=61 +ellps=WGS84",
+			"fractional UTM zone":        "+proj=utm +zone=31.5 +ellps=WGS84",
+			"missing lat_1":              "+proj=lcc +lat_0=45 +ellps=WGS84",
+			"oblique stereographic":      "+proj=stere +lat_0=45 +ellps=WGS84",
+			"wrong hemisphere":           "+proj=stere +lat_0=90 +lat_ts=-70 +ellps=WGS84",
+			"ellipsoidal gnomonic":       "+proj=gnom +lat_0=45 +ellps=WGS84",
+			"ellipsoidal orthographic":   "+proj=ortho +lat_0=45 +ellps=WGS84",
+		}
+
+		for name, s := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := ParseProjString(s, ZAxisNorth); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package nvector
+
+import (
+	"math"
+)
+
+// Quaternion is a quaternion W + Xi + Yj + Zk.
+//
+// A unit quaternion represents a rotation. The rotation is equivalent to the
+// rotation matrix returned by QuaternionToRotationMatrix, such that q.Rotate(v)
+// is the same as v.Transform(QuaternionToRotationMatrix(q)).
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// Conjugate returns the conjugate of q. For a unit quaternion, this is the
+// inverse rotation.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+// Multiply returns the Hamilton product q*r. For unit quaternions, this is the
+// rotation r followed by the rotation q, equivalent to multiplying the
+// corresponding rotation matrices in the same order.
+func (q Quaternion) Multiply(r Quaternion) Quaternion {
+	return Quaternion{
+		q.W*r.W - q.X*r.X - q.Y*r.Y - q.Z*r.Z,
+		q.W*r.X + q.X*r.W + q.Y*r.Z - q.Z*r.Y,
+		q.W*r.Y - q.X*r.Z + q.Y*r.W + q.Z*r.X,
+		q.W*r.Z + q.X*r.Y - q.Y*r.X + q.Z*r.W,
+	}
+}
+
+// Norm returns the Euclidean norm of q.
+func (q Quaternion) Norm() float64 {
+	return math.Sqrt(q.W*q.W + q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+}
+
+// Normalize returns a quaternion in the same direction as q but with norm 1.
+func (q Quaternion) Normalize() Quaternion {
+	n := q.Norm()
+
+	return Quaternion{q.W / n, q.X / n, q.Y / n, q.Z / n}
+}
+
+// Rotate returns v rotated by the unit quaternion q.
+func (q Quaternion) Rotate(v Vector) Vector {
+	// v' = v + 2w(u x v) + 2u x (u x v), where u is the vector part of q
+	u := Vector{q.X, q.Y, q.Z}
+	t := u.Cross(v).Scale(2)
+
+	return v.Add(t.Scale(q.W)).Add(u.Cross(t))
+}
+
+// QuaternionToRotationMatrix converts a unit quaternion to a rotation matrix.
+func QuaternionToRotationMatrix(q Quaternion) Matrix {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+
+	return Matrix{
+		1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y),
+		2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x),
+		2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y),
+	}
+}
+
+// RotationMatrixToQuaternion converts a rotation matrix to a unit quaternion.
+//
+// The returned quaternion has a non-negative W component. The conversion uses
+// Shepperd's method, which selects the largest of the four quaternion
+// components to divide by, so that it remains accurate for rotations of any
+// angle.
+//
+// See: https://doi.org/10.2514/3.55767b
+func RotationMatrixToQuaternion(r Matrix) Quaternion {
+	tr := r.XX + r.YY + r.ZZ
+
+	var q Quaternion
+	switch {
+	case tr >= r.XX && tr >= r.YY && tr >= r.ZZ:
+		q.W = math.Sqrt(1+tr) / 2
+		s := 4 * q.W
+		q.X = (r.ZY - r.YZ) / s
+		q.Y = (r.XZ - r.ZX) / s
+		q.Z = (r.YX - r.XY) / s
+	case r.XX >= r.YY && r.XX >= r.ZZ:
+		q.X = math.Sqrt(1+r.XX-r.YY-r.ZZ) / 2
+		s := 4 * q.X
+		q.W = (r.ZY - r.YZ) / s
+		q.Y = (r.XY + r.YX) / s
+		q.Z = (r.XZ + r.ZX) / s
+	case r.YY >= r.ZZ:
+		q.Y = math.Sqrt(1-r.XX+r.YY-r.ZZ) / 2
+		s := 4 * q.Y
+		q.W = (r.XZ - r.ZX) / s
+		q.X = (r.XY + r.YX) / s
+		q.Z = (r.YZ + r.ZY) / s
+	default:
+		q.Z = math.Sqrt(1-r.XX-r.YY+r.ZZ) / 2
+		s := 4 * q.Z
+		q.W = (r.YX - r.XY) / s
+		q.X = (r.XZ + r.ZX) / s
+		q.Y = (r.YZ + r.ZY) / s
+	}
+
+	// q and -q represent the same rotation
+	if q.W < 0 {
+		q = Quaternion{-q.W, -q.X, -q.Y, -q.Z}
+	}
+
+	return q
+}
+
+// EulerXYZToQuaternion converts Euler angles in XYZ order to a unit
+// quaternion.
+func EulerXYZToQuaternion(a EulerXYZ) Quaternion {
+	// rotations about new axes compose by multiplying on the right
+	return axisQuaternion(1, 0, 0, a.X).
+		Multiply(axisQuaternion(0, 1, 0, a.Y)).
+		Multiply(axisQuaternion(0, 0, 1, a.Z))
+}
+
+// EulerZYXToQuaternion converts Euler angles in ZYX order to a unit
+// quaternion.
+func EulerZYXToQuaternion(a EulerZYX) Quaternion {
+	// rotations about new axes compose by multiplying on the right
+	return axisQuaternion(0, 0, 1, a.Z).
+		Multiply(axisQuaternion(0, 1, 0, a.Y)).
+		Multiply(axisQuaternion(1, 0, 0, a.X))
+}
+
+// QuaternionToEulerXYZ converts a unit quaternion to Euler angles in XYZ
+// order, with the same singularity handling as RotationMatrixToEulerXYZ.
+func QuaternionToEulerXYZ(q Quaternion) EulerXYZ {
+	return RotationMatrixToEulerXYZ(QuaternionToRotationMatrix(q))
+}
+
+// QuaternionToEulerZYX converts a unit quaternion to Euler angles in ZYX
+// order, with the same singularity handling as RotationMatrixToEulerZYX.
+func QuaternionToEulerZYX(q Quaternion) EulerZYX {
+	return RotationMatrixToEulerZYX(QuaternionToRotationMatrix(q))
+}
+
+// axisQuaternion returns the unit quaternion for a rotation of a radians about
+// the unit axis (x, y, z).
+func axisQuaternion(x, y, z, a float64) Quaternion {
+	s, c := math.Sincos(a / 2)
+
+	return Quaternion{c, x * s, y * s, z * s}
+}
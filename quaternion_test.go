@@ -0,0 +1,221 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Quaternion_Multiply(t *testing.T) {
+	t.Run("it matches multiplying rotation matrices", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+			r := rapidgen.Quaternion().Draw(t, "r")
+
+			got := QuaternionToRotationMatrix(q.Multiply(r))
+			want := QuaternionToRotationMatrix(q).Multiply(QuaternionToRotationMatrix(r))
+
+			if eq, ineq := equality.EqualToMatrix(got, want, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_Quaternion_Conjugate(t *testing.T) {
+	t.Run("it is the inverse rotation", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+
+			got := q.Multiply(q.Conjugate())
+
+			if eq, ineq := equality.EqualToQuaternion(got, Quaternion{W: 1}, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_Quaternion_Normalize(t *testing.T) {
+	t.Run("it scales the quaternion to unit norm", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+			s := rapid.Float64Range(0.001, 1000).Draw(t, "scale")
+			scaled := Quaternion{W: q.W * s, X: q.X * s, Y: q.Y * s, Z: q.Z * s}
+
+			got := scaled.Normalize()
+
+			if eq, ineq := equality.EqualToFloat64(got.Norm(), 1, 1e-14); !eq {
+				equality.ReportInequality(t, "Norm", ineq)
+			}
+			if eq, ineq := equality.EqualToQuaternion(got, q, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_Quaternion_Rotate(t *testing.T) {
+	t.Run("it rotates about the axis", func(t *testing.T) {
+		q := Quaternion{W: math.Cos(math.Pi / 4), Z: math.Sin(math.Pi / 4)}
+
+		got := q.Rotate(Vector{X: 1})
+
+		if eq, ineq := equality.EqualToVector(got, Vector{Y: 1}, 1e-15); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it matches transforming by the rotation matrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+			v := rapidgen.VectorRange(-1e6, 1e6).Draw(t, "v")
+
+			got := q.Rotate(v)
+			want := v.Transform(QuaternionToRotationMatrix(q))
+
+			if eq, ineq := equality.EqualToVector(got, want, 1e-9); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_RotationMatrixToQuaternion(t *testing.T) {
+	t.Run("it is the inverse of QuaternionToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+			if q.W < 0 {
+				q = Quaternion{W: -q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+			}
+
+			got := RotationMatrixToQuaternion(QuaternionToRotationMatrix(q))
+
+			eq, ineq := equality.EqualToQuaternion(got, q, 1e-14)
+			if !eq && q.W < 1e-14 {
+				// half-turn rotations have two quaternions with W = 0
+				eq, _ = equality.EqualToQuaternion(got, Quaternion{W: -q.W, X: -q.X, Y: -q.Y, Z: -q.Z}, 1e-14)
+			}
+			if !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it round-trips rotation matrices", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			got := QuaternionToRotationMatrix(RotationMatrixToQuaternion(r))
+
+			if eq, ineq := equality.EqualToMatrix(got, r, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it handles half-turn rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			axis := rapidgen.UnitVector().Draw(t, "axis")
+			q := Quaternion{X: axis.X, Y: axis.Y, Z: axis.Z}
+
+			got := QuaternionToRotationMatrix(
+				RotationMatrixToQuaternion(QuaternionToRotationMatrix(q)),
+			)
+
+			if eq, ineq := equality.EqualToMatrix(got, QuaternionToRotationMatrix(q), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns a non-negative W component", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r := rapidgen.RotationMatrix().Draw(t, "rotation")
+
+			if got := RotationMatrixToQuaternion(r); got.W < 0 {
+				t.Errorf("got W %v; want >= 0", got.W)
+			}
+		})
+	})
+}
+
+func Test_EulerXYZToQuaternion(t *testing.T) {
+	t.Run("it matches EulerXYZToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := EulerXYZ{
+				X: rapidgen.Radians().Draw(t, "x"),
+				Y: rapidgen.Radians().Draw(t, "y"),
+				Z: rapidgen.Radians().Draw(t, "z"),
+			}
+
+			got := QuaternionToRotationMatrix(EulerXYZToQuaternion(a))
+
+			if eq, ineq := equality.EqualToMatrix(got, EulerXYZToRotationMatrix(a), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_EulerZYXToQuaternion(t *testing.T) {
+	t.Run("it matches EulerZYXToRotationMatrix", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := EulerZYX{
+				Z: rapidgen.Radians().Draw(t, "z"),
+				Y: rapidgen.Radians().Draw(t, "y"),
+				X: rapidgen.Radians().Draw(t, "x"),
+			}
+
+			got := QuaternionToRotationMatrix(EulerZYXToQuaternion(a))
+
+			if eq, ineq := equality.EqualToMatrix(got, EulerZYXToRotationMatrix(a), 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_QuaternionToEulerXYZ(t *testing.T) {
+	t.Run("it round-trips rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+			r := QuaternionToRotationMatrix(q)
+			if math.Abs(r.XZ) > 0.999 {
+				// skip rotations near the Euler angle singularity, where small errors
+				// in the matrix are magnified
+				t.Skip()
+			}
+
+			got := QuaternionToRotationMatrix(EulerXYZToQuaternion(QuaternionToEulerXYZ(q)))
+
+			if eq, ineq := equality.EqualToMatrix(got, r, 1e-13); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_QuaternionToEulerZYX(t *testing.T) {
+	t.Run("it round-trips rotations", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			q := rapidgen.Quaternion().Draw(t, "q")
+			r := QuaternionToRotationMatrix(q)
+			if math.Abs(r.ZX) > 0.999 {
+				// skip rotations near the Euler angle singularity, where small errors
+				// in the matrix are magnified
+				t.Skip()
+			}
+
+			got := QuaternionToRotationMatrix(EulerZYXToQuaternion(QuaternionToEulerZYX(q)))
+
+			if eq, ineq := equality.EqualToMatrix(got, r, 1e-13); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
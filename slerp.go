@@ -0,0 +1,219 @@
+package nvector
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Slerp returns the spherical linear interpolation between the unit
+// quaternions q and r, where t = 0 returns q and t = 1 returns r.
+//
+// The interpolation follows the shortest path between the two rotations, at a
+// constant angular velocity.
+func (q Quaternion) Slerp(r Quaternion, t float64) Quaternion {
+	// d is the rotation from q to r
+	d := q.Conjugate().Multiply(r)
+
+	// q and -q represent the same rotation, so take the shortest path
+	if d.W < 0 {
+		d = Quaternion{-d.W, -d.X, -d.Y, -d.Z}
+	}
+
+	return q.Multiply(
+		rotationVectorToQuaternion(quaternionToRotationVector(d).Scale(t)),
+	)
+}
+
+// Slerp returns the spherical linear interpolation between the rotation
+// matrices a and b, where t = 0 returns a and t = 1 returns b.
+//
+// The interpolation follows the shortest path between the two rotations, at a
+// constant angular velocity.
+func Slerp(a, b Matrix, t float64) Matrix {
+	return QuaternionToRotationMatrix(
+		RotationMatrixToQuaternion(a).Slerp(RotationMatrixToQuaternion(b), t),
+	)
+}
+
+// Squad returns the spherical cubic interpolation between the rotation
+// matrices r1 and r2, where t = 0 returns r1 and t = 1 returns r2.
+//
+// The neighboring rotations r0 and r3 are used to make the angular velocity
+// continuous when interpolating a sequence of rotations.
+//
+// See: https://doi.org/10.1145/325165.325242
+func Squad(r0, r1, r2, r3 Matrix, t float64) Matrix {
+	q1 := RotationMatrixToQuaternion(r1)
+	q0 := alignQuaternion(RotationMatrixToQuaternion(r0), q1)
+	q2 := alignQuaternion(RotationMatrixToQuaternion(r2), q1)
+	q3 := alignQuaternion(RotationMatrixToQuaternion(r3), q2)
+
+	// tangents are central differences of the neighboring rotations
+	d1 := rotationVectorBetween(q1, q2).Sub(rotationVectorBetween(q1, q0)).Scale(0.5)
+	d2 := rotationVectorBetween(q2, q3).Sub(rotationVectorBetween(q2, q1)).Scale(0.5)
+
+	return QuaternionToRotationMatrix(squad(q1, q2, d1, d2, t))
+}
+
+// OrientationSample is a rotation matrix at a point in time.
+type OrientationSample struct {
+	// Time is the time of the sample, in any unit.
+	Time float64
+	// Rotation is the rotation matrix at the time of the sample.
+	Rotation Matrix
+}
+
+// InterpolateOrientation returns the rotation matrix at time t, by spherical
+// linear interpolation between the samples either side of t.
+//
+// The samples must be sorted by time, with no two samples at the same time, and
+// t must be within the time range of the samples.
+func InterpolateOrientation(samples []OrientationSample, t float64) (Matrix, error) {
+	i, u, err := findOrientationSamples(samples, t)
+	if err != nil {
+		return Matrix{}, err
+	}
+	if u == 0 {
+		return samples[i].Rotation, nil
+	}
+
+	return Slerp(samples[i].Rotation, samples[i+1].Rotation, u), nil
+}
+
+// InterpolateOrientationCubic returns the rotation matrix at time t, by
+// spherical cubic interpolation between the samples either side of t, as
+// described by Squad.
+//
+// Unlike Squad, the angular velocity at each sample is weighted by the times
+// of its neighbors, so that samples can be irregularly spaced. At the first
+// and last samples, the angular velocity is found from the only neighbor.
+//
+// The samples must be sorted by time, with no two samples at the same time, and
+// t must be within the time range of the samples.
+func InterpolateOrientationCubic(samples []OrientationSample, t float64) (Matrix, error) {
+	i, u, err := findOrientationSamples(samples, t)
+	if err != nil {
+		return Matrix{}, err
+	}
+	if u == 0 {
+		return samples[i].Rotation, nil
+	}
+
+	q1 := RotationMatrixToQuaternion(samples[i].Rotation)
+	q2 := alignQuaternion(RotationMatrixToQuaternion(samples[i+1].Rotation), q1)
+
+	// tangents are scaled from per unit time to per interval
+	h := samples[i+1].Time - samples[i].Time
+	d1 := orientationTangent(samples, i, q1).Scale(h)
+	d2 := orientationTangent(samples, i+1, q2).Scale(h)
+
+	return QuaternionToRotationMatrix(squad(q1, q2, d1, d2, u)), nil
+}
+
+// findOrientationSamples returns the index of the sample at or before time t,
+// and the fraction of the interval to the next sample at which t lies.
+func findOrientationSamples(
+	samples []OrientationSample,
+	t float64,
+) (int, float64, error) {
+	n := len(samples)
+	if n == 0 {
+		return 0, 0, errors.New("no orientation samples")
+	}
+	if !slices.IsSortedFunc(samples, compareOrientationSamples) {
+		return 0, 0, errors.New("orientation samples are not sorted by time")
+	}
+	for i := 1; i < n; i++ {
+		if samples[i].Time == samples[i-1].Time {
+			return 0, 0, fmt.Errorf(
+				"orientation samples have duplicate time %v",
+				samples[i].Time,
+			)
+		}
+	}
+	if !(t >= samples[0].Time && t <= samples[n-1].Time) {
+		return 0, 0, fmt.Errorf(
+			"time %v is outside the orientation samples [%v, %v]",
+			t,
+			samples[0].Time,
+			samples[n-1].Time,
+		)
+	}
+
+	i, found := slices.BinarySearchFunc(
+		samples,
+		t,
+		func(s OrientationSample, t float64) int { return cmp.Compare(s.Time, t) },
+	)
+	if found {
+		return i, 0, nil
+	}
+
+	// t lies between the samples either side of the insertion point i
+	i--
+
+	return i, (t - samples[i].Time) / (samples[i+1].Time - samples[i].Time), nil
+}
+
+// compareOrientationSamples compares orientation samples by time.
+func compareOrientationSamples(a, b OrientationSample) int {
+	return cmp.Compare(a.Time, b.Time)
+}
+
+// orientationTangent returns the angular velocity at sample i as a rotation
+// vector per unit time, decomposed in the frame of the sample's rotation q.
+//
+// The angular velocity is a finite difference of the neighboring samples,
+// weighted by their times so that irregularly spaced samples of a constant
+// rotation rate produce that rate.
+func orientationTangent(samples []OrientationSample, i int, q Quaternion) Vector {
+	var d Vector
+	var dt float64
+
+	if i+1 < len(samples) {
+		next := alignQuaternion(RotationMatrixToQuaternion(samples[i+1].Rotation), q)
+		d = rotationVectorBetween(q, next)
+		dt = samples[i+1].Time - samples[i].Time
+	}
+	if i > 0 {
+		prev := alignQuaternion(RotationMatrixToQuaternion(samples[i-1].Rotation), q)
+		d = d.Sub(rotationVectorBetween(q, prev))
+		dt += samples[i].Time - samples[i-1].Time
+	}
+
+	return d.Scale(1 / dt)
+}
+
+// squad returns the spherical cubic interpolation between the unit
+// quaternions q1 and q2, where d1 and d2 are rotation vectors for the angular
+// velocity per interval at q1 and q2, decomposed in their frames.
+func squad(q1, q2 Quaternion, d1, d2 Vector, t float64) Quaternion {
+	// the inner control points are chosen so that the derivative of the
+	// interpolation at each end matches the angular velocity
+	s1 := q1.Multiply(
+		rotationVectorToQuaternion(d1.Sub(rotationVectorBetween(q1, q2)).Scale(0.5)),
+	)
+	s2 := q2.Multiply(
+		rotationVectorToQuaternion(d2.Scale(-1).Sub(rotationVectorBetween(q2, q1)).Scale(0.5)),
+	)
+
+	return q1.Slerp(q2, t).Slerp(s1.Slerp(s2, t), 2*t*(1-t))
+}
+
+// rotationVectorBetween returns the rotation vector from the unit quaternion q
+// to the unit quaternion r, decomposed in the frame of q.
+func rotationVectorBetween(q, r Quaternion) Vector {
+	return quaternionToRotationVector(alignQuaternion(q.Conjugate().Multiply(r), Quaternion{W: 1}))
+}
+
+// alignQuaternion returns q or -q, whichever is closest to r, so that both
+// represent the same rotation as q.
+func alignQuaternion(q, r Quaternion) Quaternion {
+	if q.W*r.W+q.X*r.X+q.Y*r.Y+q.Z*r.Z < 0 {
+		return Quaternion{-q.W, -q.X, -q.Y, -q.Z}
+	}
+
+	return q
+}
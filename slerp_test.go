@@ -0,0 +1,221 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_Slerp(t *testing.T) {
+	t.Run("it returns the endpoints", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := rapidgen.RotationMatrix().Draw(t, "a")
+			b := rapidgen.RotationMatrix().Draw(t, "b")
+
+			if eq, ineq := equality.EqualToMatrix(Slerp(a, b, 0), a, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToMatrix(Slerp(a, b, 1), b, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it interpolates at a constant angular velocity", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			a := rapidgen.RotationMatrix().Draw(t, "a")
+			b := rapidgen.RotationMatrix().Draw(t, "b")
+			u := rapid.Float64Range(0, 1).Draw(t, "t")
+
+			got := Slerp(a, b, u)
+			total := RotationAngle(a, b)
+
+			if eq, ineq := equality.EqualToFloat64(RotationAngle(a, got), u*total, 1e-12); !eq {
+				equality.ReportInequality(t, "Angle from a", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(RotationAngle(got, b), (1-u)*total, 1e-12); !eq {
+				equality.ReportInequality(t, "Angle to b", ineq)
+			}
+		})
+	})
+
+	t.Run("it interpolates about the axis of rotation", func(t *testing.T) {
+		got := Slerp(XAxisNorth, EulerXYZToRotationMatrix(EulerXYZ{Z: math.Pi / 2}), 0.5)
+		want := EulerXYZToRotationMatrix(EulerXYZ{Z: math.Pi / 4})
+
+		if eq, ineq := equality.EqualToMatrix(got, want, 1e-15); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it does not wrap around like Euler angles", func(t *testing.T) {
+		a := EulerZYXToRotationMatrix(EulerZYX{Z: Radians(179)})
+		b := EulerZYXToRotationMatrix(EulerZYX{Z: Radians(-179)})
+
+		got := RotationMatrixToEulerZYX(Slerp(a, b, 0.5))
+
+		if eq, ineq := equality.EqualToRadians(got.Z, math.Pi, 1e-14); !eq {
+			equality.ReportInequality(t, "Z", ineq)
+		}
+	})
+}
+
+func Test_Squad(t *testing.T) {
+	t.Run("it returns the endpoints", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			r0 := rapidgen.RotationMatrix().Draw(t, "r0")
+			r1 := rapidgen.RotationMatrix().Draw(t, "r1")
+			r2 := rapidgen.RotationMatrix().Draw(t, "r2")
+			r3 := rapidgen.RotationMatrix().Draw(t, "r3")
+
+			if eq, ineq := equality.EqualToMatrix(Squad(r0, r1, r2, r3, 0), r1, 1e-13); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToMatrix(Squad(r0, r1, r2, r3, 1), r2, 1e-13); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it reproduces a constant rotation rate", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			start := rapidgen.RotationMatrix().Draw(t, "start")
+			axis := rapidgen.UnitVector().Draw(t, "axis")
+			step := rapid.Float64Range(-1, 1).Draw(t, "step")
+			u := rapid.Float64Range(0, 1).Draw(t, "t")
+			at := func(k float64) Matrix {
+				return start.Multiply(AxisAngleToRotationMatrix(AxisAngle{axis, k * step}))
+			}
+
+			got := Squad(at(0), at(1), at(2), at(3), u)
+
+			if eq, ineq := equality.EqualToMatrix(got, at(1+u), 1e-13); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_InterpolateOrientation(t *testing.T) {
+	samples := []OrientationSample{
+		{Time: 0, Rotation: EulerZYXToRotationMatrix(EulerZYX{Z: 0})},
+		{Time: 1, Rotation: EulerZYXToRotationMatrix(EulerZYX{Z: Radians(10)})},
+		{Time: 3, Rotation: EulerZYXToRotationMatrix(EulerZYX{Z: Radians(30)})},
+		{Time: 7, Rotation: EulerZYXToRotationMatrix(EulerZYX{Z: Radians(70)})},
+	}
+
+	interpolators := map[string]func([]OrientationSample, float64) (Matrix, error){
+		"linear": InterpolateOrientation,
+		"cubic":  InterpolateOrientationCubic,
+	}
+
+	for name, interpolate := range interpolators {
+		t.Run(name, func(t *testing.T) {
+			t.Run("it returns the samples at their times", func(t *testing.T) {
+				for _, s := range samples {
+					got, err := interpolate(samples, s.Time)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if eq, ineq := equality.EqualToMatrix(got, s.Rotation, 1e-15); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+				}
+			})
+
+			t.Run("it interpolates irregularly spaced samples", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					u := rapid.Float64Range(0, 7).Draw(t, "time")
+
+					got, err := interpolate(samples, u)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					// the samples rotate at 10 degrees per unit of time
+					want := EulerZYXToRotationMatrix(EulerZYX{Z: Radians(10 * u)})
+
+					if eq, ineq := equality.EqualToMatrix(got, want, 1e-12); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+				})
+			})
+
+			t.Run("it returns an error for invalid inputs", func(t *testing.T) {
+				tests := map[string]struct {
+					samples []OrientationSample
+					t       float64
+				}{
+					"no samples":    {nil, 0},
+					"before start":  {samples, -1},
+					"after end":     {samples, 8},
+					"not a number":  {samples, math.NaN()},
+					"unsorted time": {[]OrientationSample{samples[1], samples[0]}, 0.5},
+					"duplicate time": {
+						[]OrientationSample{samples[0], samples[1], samples[1], samples[2]},
+						1.5,
+					},
+				}
+
+				for name, tt := range tests {
+					t.Run(name, func(t *testing.T) {
+						if _, err := interpolate(tt.samples, tt.t); err == nil {
+							t.Error("expected an error")
+						}
+					})
+				}
+			})
+		})
+	}
+
+	t.Run("it matches Slerp between samples", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			u := rapid.Float64Range(1, 3).Draw(t, "time")
+
+			got, err := InterpolateOrientation(samples, u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := Slerp(samples[1].Rotation, samples[2].Rotation, (u-1)/2)
+
+			if eq, ineq := equality.EqualToMatrix(got, want, 1e-15); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it reproduces a constant rotation rate from irregular samples", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			start := rapidgen.RotationMatrix().Draw(t, "start")
+			axis := rapidgen.UnitVector().Draw(t, "axis")
+			rate := rapid.Float64Range(-0.5, 0.5).Draw(t, "rate")
+			at := func(time float64) Matrix {
+				return start.Multiply(AxisAngleToRotationMatrix(AxisAngle{axis, time * rate}))
+			}
+
+			times := []float64{0}
+			for i := range rapid.IntRange(1, 5).Draw(t, "intervals") {
+				times = append(times, times[i]+rapid.Float64Range(0.1, 2).Draw(t, "interval"))
+			}
+			samples := make([]OrientationSample, len(times))
+			for i, time := range times {
+				samples[i] = OrientationSample{Time: time, Rotation: at(time)}
+			}
+			u := rapid.Float64Range(0, times[len(times)-1]).Draw(t, "time")
+
+			got, err := InterpolateOrientationCubic(samples, u)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToMatrix(got, at(u), 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
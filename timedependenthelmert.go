@@ -0,0 +1,246 @@
+package nvector
+
+import (
+	"time"
+)
+
+// TimeDependentHelmert is a 14-parameter Helmert transformation between ECEF
+// frames, such as those between realizations of the International Terrestrial
+// Reference Frame (ITRF), and from the ITRF to the European Terrestrial
+// Reference Frame (ETRF).
+//
+// The parameters at any epoch are the parameters at the reference epoch, plus
+// their rates multiplied by the time since the reference epoch. The parameters
+// use the same conventions as Helmert.
+//
+// See: https://itrf.ign.fr/en/solutions/transformations
+type TimeDependentHelmert struct {
+	// Helmert is the transformation at the reference epoch.
+	Helmert Helmert
+	// Rate is the rate of change of each parameter, per year.
+	Rate Helmert
+	// Epoch is the reference epoch, as a decimal year.
+	Epoch float64
+}
+
+var (
+	// ITRF2020ToITRF2014 is the transformation from ITRF2020 to ITRF2014.
+	//
+	// See: https://itrf.ign.fr/docs/solutions/itrf2020/Transfo-ITRF2020_TRFs.txt
+	ITRF2020ToITRF2014 = timeDependentHelmertFromPublished(
+		2015,
+		[7]float64{-1.4, -0.9, 1.4, 0, 0, 0, -0.42},
+		[7]float64{0, -0.1, 0.2, 0, 0, 0, 0},
+	)
+
+	// ITRF2014ToITRF2008 is the transformation from ITRF2014 to ITRF2008.
+	//
+	// See: https://itrf.ign.fr/docs/solutions/itrf2014/Transfo-ITRF2014_ITRFs.txt
+	ITRF2014ToITRF2008 = timeDependentHelmertFromPublished(
+		2010,
+		[7]float64{1.6, 1.9, 2.4, 0, 0, 0, -0.02},
+		[7]float64{0, 0, -0.1, 0, 0, 0, 0.03},
+	)
+
+	// ITRF2014ToITRF2005 is the transformation from ITRF2014 to ITRF2005.
+	//
+	// See: https://itrf.ign.fr/docs/solutions/itrf2014/Transfo-ITRF2014_ITRFs.txt
+	ITRF2014ToITRF2005 = timeDependentHelmertFromPublished(
+		2010,
+		[7]float64{2.6, 1.0, -2.3, 0, 0, 0, 0.92},
+		[7]float64{0.3, 0, -0.1, 0, 0, 0, 0.03},
+	)
+
+	// ITRF2014ToITRF2000 is the transformation from ITRF2014 to ITRF2000.
+	//
+	// See: https://itrf.ign.fr/docs/solutions/itrf2014/Transfo-ITRF2014_ITRFs.txt
+	ITRF2014ToITRF2000 = timeDependentHelmertFromPublished(
+		2010,
+		[7]float64{0.7, 1.2, -26.1, 0, 0, 0, 2.12},
+		[7]float64{0.1, 0.1, -1.9, 0, 0, 0, 0.11},
+	)
+
+	// ITRF2014ToITRF89 is the transformation from ITRF2014 to ITRF89.
+	//
+	// See: https://itrf.ign.fr/docs/solutions/itrf2014/Transfo-ITRF2014_ITRFs.txt
+	ITRF2014ToITRF89 = timeDependentHelmertFromPublished(
+		2010,
+		[7]float64{30.4, 35.5, -130.8, 0, 0, 0.26, 8.19},
+		[7]float64{0.1, -0.5, -3.3, 0, 0, 0.02, 0.12},
+	)
+
+	// ITRF2014ToETRF2014 is the transformation from ITRF2014 to ETRF2014,
+	// which follows the rotation of the stable part of the Eurasian plate, and
+	// coincides with ITRF2014 at epoch 1989.0. The rates are from EUREF
+	// Technical Note 1 by Boucher and Altamimi.
+	ITRF2014ToETRF2014 = timeDependentHelmertFromPublished(
+		1989,
+		[7]float64{},
+		[7]float64{0, 0, 0, 0.085, 0.531, -0.770, 0},
+	)
+
+	// ITRF89ToETRF89 is the transformation from ITRF89 to ETRF89, which
+	// coincides with ITRF89 at epoch 1989. The rates are from EUREF Technical
+	// Note 1 by Boucher and Altamimi.
+	ITRF89ToETRF89 = timeDependentHelmertFromPublished(
+		1989,
+		[7]float64{},
+		[7]float64{0, 0, 0, 0.11, 0.57, -0.71, 0},
+	)
+
+	// ITRF2014ToETRF89 is the transformation from ITRF2014 to ETRF89, by way of
+	// ITRF89, i.e. ITRF2014ToITRF89.Then(ITRF89ToETRF89).
+	ITRF2014ToETRF89 = ITRF2014ToITRF89.Then(ITRF89ToETRF89)
+)
+
+// At returns the Helmert transformation at the epoch t, as a decimal year.
+func (h TimeDependentHelmert) At(t float64) Helmert {
+	dt := t - h.Epoch
+
+	return Helmert{
+		h.Helmert.TX + h.Rate.TX*dt,
+		h.Helmert.TY + h.Rate.TY*dt,
+		h.Helmert.TZ + h.Rate.TZ*dt,
+		h.Helmert.RX + h.Rate.RX*dt,
+		h.Helmert.RY + h.Rate.RY*dt,
+		h.Helmert.RZ + h.Rate.RZ*dt,
+		h.Helmert.Scale + h.Rate.Scale*dt,
+	}
+}
+
+// Inverse returns the time-dependent Helmert transformation that reverses h,
+// by reversing the signs of the parameters and their rates, as described by
+// Helmert.Inverse.
+func (h TimeDependentHelmert) Inverse() TimeDependentHelmert {
+	return TimeDependentHelmert{h.Helmert.Inverse(), h.Rate.Inverse(), h.Epoch}
+}
+
+// Then returns the time-dependent Helmert transformation that applies h
+// followed by g, at the reference epoch of h.
+//
+// The parameters of g at the reference epoch of h, and the rates, are summed
+// as described by Helmert.Then, which is accurate to first order in the
+// rotations and scale changes.
+func (h TimeDependentHelmert) Then(g TimeDependentHelmert) TimeDependentHelmert {
+	return TimeDependentHelmert{
+		h.Helmert.Then(g.At(h.Epoch)),
+		h.Rate.Then(g.Rate),
+		h.Epoch,
+	}
+}
+
+// Transform returns the station s transformed by h at the epoch of the
+// station. The coordinate frame f specifies the axes in which the position and
+// velocity of s, and of the returned station, are decomposed.
+func (h TimeDependentHelmert) Transform(s Station, f Matrix) Station {
+	at := h.At(s.Epoch)
+
+	// m changes the axes from f to ZAxisNorth
+	m := ZAxisNorth.Transpose().Multiply(f)
+	x := s.Position.Transform(m)
+	v := s.Velocity.Transform(m)
+
+	// the velocity is the derivative of the transformed position
+	v = h.Rate.translation().
+		Add(x.Transform(h.matrixRate(at))).
+		Add(v.Transform(at.matrix()))
+
+	return Station{
+		Position: at.Transform(s.Position, f),
+		Velocity: v.Transform(m.Transpose()),
+		Epoch:    s.Epoch,
+	}
+}
+
+// InverseTransform returns the station s transformed by the exact inverse of
+// h at the epoch of the station, such that h.InverseTransform(h.Transform(s,
+// f), f) returns s. The coordinate frame f specifies the axes in which the
+// position and velocity of s, and of the returned station, are decomposed.
+func (h TimeDependentHelmert) InverseTransform(s Station, f Matrix) Station {
+	at := h.At(s.Epoch)
+	p := at.InverseTransform(s.Position, f)
+
+	// m changes the axes from f to ZAxisNorth
+	m := ZAxisNorth.Transpose().Multiply(f)
+	x := p.Transform(m)
+	v := s.Velocity.Transform(m)
+
+	// the linearized rotation matrix is invertible for any realistic rotation
+	inv, _ := at.matrix().Inverse()
+
+	// reverse the derivative of the transformed position
+	v = v.Sub(h.Rate.translation()).
+		Sub(x.Transform(h.matrixRate(at))).
+		Transform(inv)
+
+	return Station{
+		Position: p,
+		Velocity: v.Transform(m.Transpose()),
+		Epoch:    s.Epoch,
+	}
+}
+
+// matrixRate returns the rate of change of the linearized rotation matrix of
+// h, including the scale change, when the parameters are those of at.
+func (h TimeDependentHelmert) matrixRate(at Helmert) Matrix {
+	s := 1 + at.Scale
+	ds := h.Rate.Scale
+
+	return Matrix{
+		ds, -ds*at.RZ - s*h.Rate.RZ, ds*at.RY + s*h.Rate.RY,
+		ds*at.RZ + s*h.Rate.RZ, ds, -ds*at.RX - s*h.Rate.RX,
+		-ds*at.RY - s*h.Rate.RY, ds*at.RX + s*h.Rate.RX, ds,
+	}
+}
+
+// Station is an ECEF position at an epoch, with an optional velocity, such as
+// the coordinates of a GNSS reference station.
+type Station struct {
+	// Position is the ECEF position vector in meters.
+	Position Vector
+	// Velocity is the ECEF velocity vector in meters per year, or the zero
+	// vector if the velocity is unknown.
+	Velocity Vector
+	// Epoch is the epoch of the position, as a decimal year.
+	Epoch float64
+}
+
+// Propagate returns the station s moved to the epoch t, as a decimal year, by
+// applying its velocity, e.g. to account for plate motion.
+func (s Station) Propagate(t float64) Station {
+	return Station{
+		Position: s.Position.Add(s.Velocity.Scale(t - s.Epoch)),
+		Velocity: s.Velocity,
+		Epoch:    t,
+	}
+}
+
+// DecimalYear returns the time t as a decimal year, e.g. 2023.5 for midday on
+// July 2, 2023 UTC.
+func DecimalYear(t time.Time) float64 {
+	t = t.UTC()
+	start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	return float64(t.Year()) + float64(t.Sub(start))/float64(end.Sub(start))
+}
+
+// timeDependentHelmertFromPublished returns a time-dependent Helmert
+// transformation from parameters and rates in the units that are usually
+// published for the ITRF: translations in millimeters, rotations in
+// milliarcseconds, and the scale change in parts per billion. The parameters
+// are in the order TX, TY, TZ, RX, RY, RZ, and scale.
+func timeDependentHelmertFromPublished(
+	epoch float64,
+	params, rates [7]float64,
+) TimeDependentHelmert {
+	convert := func(p [7]float64) Helmert {
+		return helmertFromPublished(
+			p[0]/1000, p[1]/1000, p[2]/1000,
+			p[3]/1000, p[4]/1000, p[5]/1000,
+			p[6]/1000,
+		)
+	}
+
+	return TimeDependentHelmert{convert(params), convert(rates), epoch}
+}
@@ -0,0 +1,237 @@
+package nvector_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_TimeDependentHelmert(t *testing.T) {
+	transformations := map[string]TimeDependentHelmert{
+		"ITRF2020ToITRF2014": ITRF2020ToITRF2014,
+		"ITRF2014ToITRF2008": ITRF2014ToITRF2008,
+		"ITRF2014ToITRF2005": ITRF2014ToITRF2005,
+		"ITRF2014ToITRF2000": ITRF2014ToITRF2000,
+		"ITRF2014ToETRF2014": ITRF2014ToETRF2014,
+		"ITRF2014ToITRF89":   ITRF2014ToITRF89,
+		"ITRF89ToETRF89":     ITRF89ToETRF89,
+		"ITRF2014ToETRF89":   ITRF2014ToETRF89,
+	}
+
+	for name, h := range transformations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("it uses the published parameters at the reference epoch", func(t *testing.T) {
+				if got := h.At(h.Epoch); got != h.Helmert {
+					t.Errorf("got %+v; want %+v", got, h.Helmert)
+				}
+			})
+
+			t.Run("it transforms velocities consistently with positions", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					s := Station{
+						Position: rapidgen.EcefVector(GRS80).Draw(t, "position"),
+						Velocity: rapidgen.VectorRange(-0.1, 0.1).Draw(t, "velocity"),
+						Epoch:    rapid.Float64Range(1980, 2040).Draw(t, "epoch"),
+					}
+					f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+					// positions and parameters are linear in time, so the central
+					// difference is exact up to rounding
+					before := h.Transform(s.Propagate(s.Epoch-1), f).Position
+					after := h.Transform(s.Propagate(s.Epoch+1), f).Position
+					want := after.Sub(before).Scale(0.5)
+
+					got := h.Transform(s, f).Velocity
+
+					if eq, ineq := equality.EqualToVector(got, want, 1e-8); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+				})
+			})
+
+			t.Run("it reverses exactly with InverseTransform", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					s := Station{
+						Position: rapidgen.EcefVector(GRS80).Draw(t, "position"),
+						Velocity: rapidgen.VectorRange(-0.1, 0.1).Draw(t, "velocity"),
+						Epoch:    rapid.Float64Range(1980, 2040).Draw(t, "epoch"),
+					}
+					f := rapidgen.RotationMatrix().Draw(t, "frame")
+
+					got := h.InverseTransform(h.Transform(s, f), f)
+
+					if eq, ineq := equality.EqualToVector(got.Position, s.Position, 1e-7); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+					if eq, ineq := equality.EqualToVector(got.Velocity, s.Velocity, 1e-15); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+				})
+			})
+
+			t.Run("it approximately reverses with Inverse", func(t *testing.T) {
+				rapid.Check(t, func(t *rapid.T) {
+					s := Station{
+						Position: rapidgen.EcefVector(GRS80).Draw(t, "position"),
+						Epoch:    rapid.Float64Range(1980, 2040).Draw(t, "epoch"),
+					}
+
+					got := h.Inverse().Transform(h.Transform(s, ZAxisNorth), ZAxisNorth)
+
+					if eq, ineq := equality.EqualToVector(got.Position, s.Position, 1e-6); !eq {
+						equality.ReportInequalities(t, ineq)
+					}
+				})
+			})
+		})
+	}
+
+	t.Run("it removes the motion of the Eurasian plate in ETRF2014", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			c := GeodeticCoordinates{
+				Latitude:  Radians(rapid.Float64Range(35, 70).Draw(t, "latitude")),
+				Longitude: Radians(rapid.Float64Range(-10, 30).Draw(t, "longitude")),
+			}
+			p := ToECEF(Position{Vector: FromGeodeticCoordinates(c, ZAxisNorth)}, GRS80, ZAxisNorth)
+
+			// the ITRF2014 plate motion model rotation pole of Eurasia, in
+			// radians per year
+			omega := Vector{
+				X: Radians(-0.085 / 3600000),
+				Y: Radians(-0.531 / 3600000),
+				Z: Radians(0.770 / 3600000),
+			}
+			s := Station{
+				Position: p,
+				Velocity: omega.Cross(p),
+				Epoch:    rapid.Float64Range(1989, 2040).Draw(t, "epoch"),
+			}
+
+			if speed := s.Velocity.Norm(); speed < 0.015 || speed > 0.03 {
+				t.Fatalf("got plate speed %v m/yr; want between 0.015 and 0.03", speed)
+			}
+
+			got := ITRF2014ToETRF2014.Transform(s, ZAxisNorth)
+
+			if eq, ineq := equality.EqualToVector(got.Velocity, Vector{}, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_TimeDependentHelmert_ETRF89(t *testing.T) {
+	t.Run("it transforms a station from ITRF89 to ETRF89", func(t *testing.T) {
+		// a station near Onsala, Sweden, with the ETRF89 values calculated by
+		// hand from the rotation rates in EUREF Technical Note 1
+		s := Station{
+			Position: Vector{X: 3370658.0, Y: 711877.0, Z: 5349787.0},
+			Epoch:    2000,
+		}
+
+		got := ITRF89ToETRF89.Transform(s, ZAxisNorth)
+
+		want := Station{
+			Position: Vector{X: 3370658.18958, Y: 711876.84099, Z: 5349786.90172},
+			Velocity: Vector{X: 0.01723, Y: -0.01446, Z: -0.00893},
+			Epoch:    2000,
+		}
+
+		if eq, ineq := equality.EqualToVector(got.Position, want.Position, 1e-5); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+		if eq, ineq := equality.EqualToVector(got.Velocity, want.Velocity, 1e-5); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it coincides with ITRF89 at epoch 1989", func(t *testing.T) {
+		s := Station{
+			Position: Vector{X: 3370658.0, Y: 711877.0, Z: 5349787.0},
+			Epoch:    1989,
+		}
+
+		got := ITRF89ToETRF89.Transform(s, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToVector(got.Position, s.Position, 0); !eq {
+			equality.ReportInequalities(t, ineq)
+		}
+	})
+
+	t.Run("it chains ITRF2014 to ETRF89 through ITRF89", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			s := Station{
+				Position: rapidgen.EcefVector(GRS80).Draw(t, "position"),
+				Velocity: rapidgen.VectorRange(-0.1, 0.1).Draw(t, "velocity"),
+				Epoch:    rapid.Float64Range(1980, 2040).Draw(t, "epoch"),
+			}
+
+			got := ITRF2014ToETRF89.Transform(s, ZAxisNorth)
+			want := ITRF89ToETRF89.Transform(
+				ITRF2014ToITRF89.Transform(s, ZAxisNorth),
+				ZAxisNorth,
+			)
+
+			// the composition is accurate to first order in the rotations and
+			// scale changes
+			if eq, ineq := equality.EqualToVector(got.Position, want.Position, 1e-6); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+			if eq, ineq := equality.EqualToVector(got.Velocity, want.Velocity, 1e-8); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_Station_Propagate(t *testing.T) {
+	s := Station{
+		Position: Vector{X: 4027893.6, Y: 307045.6, Z: 4919475.0},
+		Velocity: Vector{X: -0.0134, Y: 0.0178, Z: 0.0098},
+		Epoch:    2010,
+	}
+
+	got := s.Propagate(2020.5)
+	want := Station{
+		Position: Vector{X: 4027893.4593, Y: 307045.7869, Z: 4919475.1029},
+		Velocity: s.Velocity,
+		Epoch:    2020.5,
+	}
+
+	if eq, ineq := equality.EqualToVector(got.Position, want.Position, 1e-9); !eq {
+		equality.ReportInequalities(t, ineq)
+	}
+	if eq, ineq := equality.EqualToVector(got.Velocity, want.Velocity, 0); !eq {
+		equality.ReportInequalities(t, ineq)
+	}
+	if eq, ineq := equality.EqualToFloat64(got.Epoch, want.Epoch, 0); !eq {
+		equality.ReportInequality(t, "Epoch", ineq)
+	}
+}
+
+func Test_DecimalYear(t *testing.T) {
+	tests := map[string]struct {
+		t    time.Time
+		want float64
+	}{
+		"start of year":     {time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), 2023},
+		"middle of year":    {time.Date(2023, 7, 2, 12, 0, 0, 0, time.UTC), 2023.5},
+		"leap year":         {time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), 2024.5},
+		"end of year":       {time.Date(2023, 12, 31, 24, 0, 0, 0, time.UTC), 2024},
+		"other time zone":   {time.Date(2023, 1, 1, 2, 0, 0, 0, time.FixedZone("", 7200)), 2023},
+		"reference epoch":   {time.Date(1989, 1, 1, 0, 0, 0, 0, time.UTC), 1989},
+		"quarter of a year": {time.Date(2021, 4, 2, 6, 0, 0, 0, time.UTC), 2021.25},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if eq, ineq := equality.EqualToFloat64(DecimalYear(tt.t), tt.want, 1e-12); !eq {
+				equality.ReportInequality(t, "DecimalYear", ineq)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package nvector
+
+import (
+	"fmt"
+	"math"
+)
+
+// TransverseMercator is a transverse Mercator projection.
+//
+// The projection is computed using the 6th-order Krüger series, which is
+// accurate to a few nanometers within 3900km of the central meridian.
+//
+// See: https://arxiv.org/abs/1002.1417
+type TransverseMercator struct {
+	// Ellipsoid is the reference ellipsoid.
+	Ellipsoid Ellipsoid
+	// Origin is the natural origin of the projection. Its longitude is the
+	// central meridian.
+	Origin GeodeticCoordinates
+	// ScaleFactor is the scale factor on the central meridian.
+	ScaleFactor float64
+	// FalseEasting and FalseNorthing are the projected coordinates of the natural
+	// origin, in meters.
+	FalseEasting, FalseNorthing float64
+}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// An error is returned if the n-vector is 90° or more of longitude away from
+// the central meridian.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p TransverseMercator) Forward(
+	v Vector,
+	f Matrix,
+) (ProjectedCoordinates, error) {
+	lat, lon, err := p.relative(v, f)
+	if err != nil {
+		return ProjectedCoordinates{}, err
+	}
+
+	x, y, _, _ := krugerForward(lat, lon, p.Ellipsoid)
+
+	return ProjectedCoordinates{
+		p.FalseEasting + p.ScaleFactor*x,
+		p.FalseNorthing + p.ScaleFactor*(y-p.originY()),
+	}, nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p TransverseMercator) Inverse(
+	c ProjectedCoordinates,
+	f Matrix,
+) (Vector, error) {
+	lat, lon := krugerInverse(
+		(c.Easting-p.FalseEasting)/p.ScaleFactor,
+		(c.Northing-p.FalseNorthing)/p.ScaleFactor+p.originY(),
+		p.Ellipsoid,
+	)
+
+	return FromGeodeticCoordinates(
+		GeodeticCoordinates{lat, p.Origin.Longitude + lon},
+		f,
+	), nil
+}
+
+// Distortion returns the point scale factor and meridian convergence of the
+// projection at an n-vector.
+//
+// An error is returned if the n-vector is 90° or more of longitude away from
+// the central meridian.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p TransverseMercator) Distortion(v Vector, f Matrix) (Distortion, error) {
+	lat, lon, err := p.relative(v, f)
+	if err != nil {
+		return Distortion{}, err
+	}
+
+	_, _, k, gamma := krugerForward(lat, lon, p.Ellipsoid)
+
+	return Distortion{p.ScaleFactor * k, p.ScaleFactor * k, gamma}, nil
+}
+
+// relative returns the latitude of an n-vector, and its longitude relative to
+// the central meridian.
+func (p TransverseMercator) relative(
+	v Vector,
+	f Matrix,
+) (lat, lon float64, err error) {
+	c := ToGeodeticCoordinates(v, f)
+	lon = math.Remainder(c.Longitude-p.Origin.Longitude, 2*math.Pi)
+
+	if math.Abs(lon) >= math.Pi/2 {
+		return 0, 0, fmt.Errorf(
+			"longitude %v° is too far from the central meridian %v°",
+			Degrees(c.Longitude),
+			Degrees(p.Origin.Longitude),
+		)
+	}
+
+	return c.Latitude, lon, nil
+}
+
+// originY returns the unscaled northing of the natural origin, relative to the
+// equator.
+func (p TransverseMercator) originY() float64 {
+	_, y, _, _ := krugerForward(p.Origin.Latitude, 0, p.Ellipsoid)
+
+	return y
+}
+
+// krugerForward projects geodetic coordinates onto a transverse Mercator
+// projection with a unit scale factor, using the 6th-order Krüger series.
+//
+// lon is the longitude relative to the central meridian, in the range
+// (-pi/2, pi/2). The returned x and y are the distances east and north of the
+// intersection of the central meridian and the equator, k is the point scale
+// factor, and gamma is the grid convergence in radians.
+//
+// See: https://arxiv.org/abs/1002.1417
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/utm.js
+func krugerForward(lat, lon float64, e Ellipsoid) (x, y, k, gamma float64) {
+	ecc := e.Eccentricity()
+	n := e.Flattening / (2 - e.Flattening)
+	alpha := krugerAlpha(n)
+	a := krugerRectifyingRadius(e.SemiMajorAxis, n)
+
+	cLon := math.Cos(lon)
+	sLon := math.Sin(lon)
+
+	// Equation (7) in Karney (2011), the conformal latitude as tau' = tan(chi):
+	tau := math.Tan(lat)
+	tauP := conformalTau(tau, e)
+
+	// Equation (10) in Karney (2011), the Gauss-Schreiber coordinates:
+	xiP := math.Atan2(tauP, cLon)
+	etaP := math.Asinh(sLon / math.Sqrt(tauP*tauP+cLon*cLon))
+
+	// Equations (11), (23) and (24) in Karney (2011):
+	xi, eta := xiP, etaP
+	pP, qP := 1.0, 0.0
+	for j := 1; j <= 6; j++ {
+		j2 := float64(2 * j)
+		s, c := math.Sincos(j2 * xiP)
+		sh, ch := math.Sinh(j2*etaP), math.Cosh(j2*etaP)
+
+		xi += alpha[j-1] * s * ch
+		eta += alpha[j-1] * c * sh
+		pP += j2 * alpha[j-1] * c * ch
+		qP += j2 * alpha[j-1] * s * sh
+	}
+
+	x = a * eta
+	y = a * xi
+
+	// Equations (25) and (26) in Karney (2011), the convergence and scale:
+	gammaP := math.Atan(tauP / math.Sqrt(1+tauP*tauP) * math.Tan(lon))
+	gammaPP := math.Atan2(qP, pP)
+	gamma = gammaP + gammaPP
+
+	sLat := math.Sin(lat)
+	kP := math.Sqrt(1-ecc*ecc*sLat*sLat) * math.Sqrt(1+tau*tau) /
+		math.Sqrt(tauP*tauP+cLon*cLon)
+	kPP := a / e.SemiMajorAxis * math.Hypot(pP, qP)
+	k = kP * kPP
+
+	return x, y, k, gamma
+}
+
+// krugerInverse is the inverse of krugerForward.
+//
+// x and y are the distances east and north of the intersection of the central
+// meridian and the equator. The returned lon is the longitude relative to the
+// central meridian.
+//
+// See: https://arxiv.org/abs/1002.1417
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/utm.js
+func krugerInverse(x, y float64, e Ellipsoid) (lat, lon float64) {
+	n := e.Flattening / (2 - e.Flattening)
+	beta := krugerBeta(n)
+	a := krugerRectifyingRadius(e.SemiMajorAxis, n)
+
+	xi := y / a
+	eta := x / a
+
+	// Equation (11) in Karney (2011), reversed:
+	xiP, etaP := xi, eta
+	for j := 1; j <= 6; j++ {
+		j2 := float64(2 * j)
+		s, c := math.Sincos(j2 * xi)
+
+		xiP -= beta[j-1] * s * math.Cosh(j2*eta)
+		etaP -= beta[j-1] * c * math.Sinh(j2*eta)
+	}
+
+	shEtaP := math.Sinh(etaP)
+	sXiP, cXiP := math.Sincos(xiP)
+
+	tauP := sXiP / math.Sqrt(shEtaP*shEtaP+cXiP*cXiP)
+
+	// Equations (19) to (21) in Karney (2011), solving for tau:
+	tau := conformalTauInverse(tauP, e)
+
+	return math.Atan(tau), math.Atan2(shEtaP, cXiP)
+}
+
+// krugerRectifyingRadius returns the radius of the sphere with the same
+// meridian length as the ellipsoid, multiplied by 2pi.
+//
+// See: Equation (14) in Karney (2011).
+func krugerRectifyingRadius(a, n float64) float64 {
+	n2 := n * n
+
+	return a / (1 + n) * (1 + n2/4 + n2*n2/64 + n2*n2*n2/256)
+}
+
+// krugerAlpha returns the coefficients of the Krüger series used to convert
+// Gauss-Schreiber coordinates to transverse Mercator coordinates.
+//
+// See: Equation (35) in Karney (2011).
+func krugerAlpha(n float64) [6]float64 {
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	return [6]float64{
+		n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+		13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+		61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+		49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+		34729*n5/80640 - 3418889*n6/1995840,
+		212378941 * n6 / 319334400,
+	}
+}
+
+// krugerBeta returns the coefficients of the Krüger series used to convert
+// transverse Mercator coordinates to Gauss-Schreiber coordinates.
+//
+// See: Equation (36) in Karney (2011).
+func krugerBeta(n float64) [6]float64 {
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	n5 := n4 * n
+	n6 := n5 * n
+
+	return [6]float64{
+		n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+		n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+		17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+		4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+		4583*n5/161280 - 108847*n6/3991680,
+		20648693 * n6 / 638668800,
+	}
+}
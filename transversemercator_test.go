@@ -0,0 +1,173 @@
+package nvector_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+// osgbNationalGrid is the British National Grid, using the Airy 1830
+// ellipsoid.
+//
+// See: EPSG Guidance Note 7-2, Section 3.5.3.1
+var osgbNationalGrid = TransverseMercator{
+	Ellipsoid:     Ellipsoid{6377563.396, 6377563.396 * (1 - 1/299.3249646), 1 / 299.3249646},
+	Origin:        GeodeticCoordinates{Radians(49), Radians(-2)},
+	ScaleFactor:   0.9996012717,
+	FalseEasting:  400000,
+	FalseNorthing: -100000,
+}
+
+func Test_TransverseMercator_Forward(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(50.5), Radians(0.5)},
+			ZAxisNorth,
+		)
+
+		got, err := osgbNationalGrid.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.Easting, 577274.99, 1e-2); !eq {
+			equality.ReportInequality(t, "Easting", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Northing, 69740.50, 1e-2); !eq {
+			equality.ReportInequality(t, "Northing", ineq)
+		}
+	})
+
+	t.Run("it returns an error far from the central meridian", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(10), Radians(88)},
+			ZAxisNorth,
+		)
+
+		if _, err := osgbNationalGrid.Forward(v, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_TransverseMercator_Inverse(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v, err := osgbNationalGrid.Inverse(
+			ProjectedCoordinates{577274.99, 69740.50},
+			ZAxisNorth,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ToGeodeticCoordinates(v, ZAxisNorth)
+
+		if eq, ineq := equality.EqualToRadians(got.Latitude, Radians(50.5), 5e-9); !eq {
+			equality.ReportInequality(t, "Latitude", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Longitude, Radians(0.5), 5e-9); !eq {
+			equality.ReportInequality(t, "Longitude", ineq)
+		}
+	})
+
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := TransverseMercator{
+				Ellipsoid:     rapidgen.Ellipsoid().Draw(t, "ellipsoid"),
+				Origin:        rapidgen.GeodeticCoordinates().Draw(t, "origin"),
+				ScaleFactor:   rapid.Float64Range(0.9, 1.1).Draw(t, "scaleFactor"),
+				FalseEasting:  rapid.Float64Range(-1e6, 1e6).Draw(t, "falseEasting"),
+				FalseNorthing: rapid.Float64Range(-1e7, 1e7).Draw(t, "falseNorthing"),
+			}
+			p.Origin.Latitude = Radians(p.Origin.Latitude)
+			p.Origin.Longitude = Radians(p.Origin.Longitude)
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{
+					Latitude: Radians(rapid.Float64Range(-89, 89).Draw(t, "latitude")),
+					Longitude: p.Origin.Longitude +
+						Radians(rapid.Float64Range(-30, 30).Draw(t, "longitude")),
+				},
+				f,
+			)
+
+			c, err := p.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_TransverseMercator_Distortion(t *testing.T) {
+	t.Run("it has no distortion on the central meridian", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(55), Radians(-2)},
+			ZAxisNorth,
+		)
+
+		got, err := osgbNationalGrid.Distortion(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := osgbNationalGrid.ScaleFactor
+		if eq, ineq := equality.EqualToFloat64(got.MeridianScale, want, 1e-15); !eq {
+			equality.ReportInequality(t, "MeridianScale", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.ParallelScale, want, 1e-15); !eq {
+			equality.ReportInequality(t, "ParallelScale", ineq)
+		}
+		if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 1e-15); !eq {
+			equality.ReportInequality(t, "Convergence", ineq)
+		}
+	})
+
+	t.Run("it matches the spherical formulas", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			p := TransverseMercator{Ellipsoid: Sphere(6371e3), ScaleFactor: 0.9996}
+			lat := Radians(rapid.Float64Range(-89, 89).Draw(t, "latitude"))
+			lon := Radians(rapid.Float64Range(-60, 60).Draw(t, "longitude"))
+			v := FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, ZAxisNorth)
+
+			got, err := p.Distortion(v, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Equations (8-7) and (8-8) in Snyder (1987)
+			b := math.Cos(lat) * math.Sin(lon)
+			wantK := p.ScaleFactor / math.Sqrt(1-b*b)
+			wantGamma := math.Atan(math.Tan(lon) * math.Sin(lat))
+
+			if eq, ineq := equality.EqualToFloat64(got.ParallelScale, wantK, 1e-12); !eq {
+				equality.ReportInequality(t, "ParallelScale", ineq)
+			}
+			if eq, ineq := equality.EqualToRadians(got.Convergence, wantGamma, 1e-12); !eq {
+				equality.ReportInequality(t, "Convergence", ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error far from the central meridian", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(10), Radians(-120)},
+			ZAxisNorth,
+		)
+
+		if _, err := osgbNationalGrid.Distortion(v, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
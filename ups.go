@@ -0,0 +1,124 @@
+package nvector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// upsScaleFactor is the scale factor at the poles of the UPS projections.
+	upsScaleFactor = 0.994
+	// upsFalseEasting and upsFalseNorthing are the projected coordinates of the
+	// poles of the UPS projections.
+	upsFalseEasting, upsFalseNorthing = 2000000, 2000000
+)
+
+// UPSCoordinates is a position expressed in the Universal Polar Stereographic
+// (UPS) coordinate system.
+//
+// Easting and Northing are given in meters, and are both 2,000km at the pole.
+type UPSCoordinates struct {
+	Hemisphere        Hemisphere
+	Easting, Northing float64
+}
+
+// ParseUPS parses UPS coordinates from a string.
+//
+// The string must contain the hemisphere, easting, and northing separated by
+// whitespace, e.g. "N 2131976 1909678".
+func ParseUPS(s string) (UPSCoordinates, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return UPSCoordinates{}, fmt.Errorf("invalid UPS coordinates %q", s)
+	}
+
+	var h Hemisphere
+	switch strings.ToUpper(fields[0]) {
+	case "N":
+		h = NorthernHemisphere
+	case "S":
+		h = SouthernHemisphere
+	default:
+		return UPSCoordinates{}, fmt.Errorf("invalid UPS hemisphere %q", fields[0])
+	}
+
+	easting, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return UPSCoordinates{}, fmt.Errorf("invalid UPS easting %q", fields[1])
+	}
+	northing, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return UPSCoordinates{}, fmt.Errorf("invalid UPS northing %q", fields[2])
+	}
+
+	return UPSCoordinates{h, easting, northing}, nil
+}
+
+// Format formats UPS coordinates as a string, with the easting and northing
+// rounded to the given number of decimal places, e.g. "N 2131976.031
+// 1909678.372".
+func (c UPSCoordinates) Format(decimals int) string {
+	return fmt.Sprintf(
+		"%s %.*f %.*f",
+		c.Hemisphere,
+		decimals,
+		c.Easting,
+		decimals,
+		c.Northing,
+	)
+}
+
+// String formats UPS coordinates as a string, with the easting and northing
+// rounded to the nearest meter.
+func (c UPSCoordinates) String() string {
+	return c.Format(0)
+}
+
+// ToUPS converts an n-vector to UPS coordinates.
+//
+// The hemisphere is selected according to the latitude of the n-vector. UPS is
+// normally only used north of 84°N and south of 80°S, but the coordinates of
+// any position are returned.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToUPS(v Vector, e Ellipsoid, f Matrix) UPSCoordinates {
+	h := NorthernHemisphere
+	if ToGeodeticCoordinates(v, f).Latitude < 0 {
+		h = SouthernHemisphere
+	}
+
+	// The opposite pole is never in the selected hemisphere
+	p, _ := upsProjection(e, h).Forward(v, f)
+
+	return UPSCoordinates{h, p.Easting, p.Northing}
+}
+
+// FromUPS converts UPS coordinates to an n-vector.
+//
+// An error is returned if the hemisphere is invalid.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func FromUPS(c UPSCoordinates, e Ellipsoid, f Matrix) (Vector, error) {
+	if c.Hemisphere != NorthernHemisphere &&
+		c.Hemisphere != SouthernHemisphere {
+		return Vector{}, fmt.Errorf("invalid UPS hemisphere %v", c.Hemisphere)
+	}
+
+	return upsProjection(e, c.Hemisphere).Inverse(
+		ProjectedCoordinates{c.Easting, c.Northing},
+		f,
+	)
+}
+
+// upsProjection returns the polar stereographic projection used for a UPS
+// hemisphere.
+func upsProjection(e Ellipsoid, h Hemisphere) PolarStereographic {
+	return PolarStereographic{
+		Ellipsoid:     e,
+		Hemisphere:    h,
+		ScaleFactor:   upsScaleFactor,
+		FalseEasting:  upsFalseEasting,
+		FalseNorthing: upsFalseNorthing,
+	}
+}
@@ -0,0 +1,118 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ToUPS(t *testing.T) {
+	tests := map[string]struct {
+		lat, lon float64
+		want     UPSCoordinates
+	}{
+		"north pole":   {90, 0, UPSCoordinates{NorthernHemisphere, 2000000, 2000000}},
+		"south pole":   {-90, 0, UPSCoordinates{SouthernHemisphere, 2000000, 2000000}},
+		"EPSG example": {73, 44, UPSCoordinates{NorthernHemisphere, 3320416.75, 632668.43}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			got := ToUPS(v, WGS84, ZAxisNorth)
+
+			if got.Hemisphere != tt.want.Hemisphere {
+				t.Errorf("got hemisphere %v; want %v", got.Hemisphere, tt.want.Hemisphere)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Easting, tt.want.Easting, 1e-2); !eq {
+				equality.ReportInequality(t, "Easting", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Northing, tt.want.Northing, 1e-2); !eq {
+				equality.ReportInequality(t, "Northing", ineq)
+			}
+		})
+	}
+}
+
+func Test_FromUPS(t *testing.T) {
+	t.Run("it is the inverse of ToUPS", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			c := rapidgen.GeodeticCoordinates().Draw(t, "coords")
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(c.Latitude), Radians(c.Longitude)},
+				f,
+			)
+
+			got, err := FromUPS(ToUPS(v, e, f), e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error for invalid hemispheres", func(t *testing.T) {
+		c := UPSCoordinates{Hemisphere(2), 2000000, 2000000}
+
+		if _, err := FromUPS(c, WGS84, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_ParseUPS(t *testing.T) {
+	t.Run("it parses UPS coordinates", func(t *testing.T) {
+		want := UPSCoordinates{NorthernHemisphere, 2131976, 1909678}
+
+		for _, s := range []string{
+			"N 2131976 1909678",
+			"n  2131976.0 1909678",
+		} {
+			got, err := ParseUPS(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != want {
+				t.Errorf("ParseUPS(%q) = %v; want %v", s, got, want)
+			}
+		}
+	})
+
+	t.Run("it returns an error for invalid strings", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"N 2131976",
+			"X 2131976 1909678",
+			"N x 1909678",
+			"N 2131976 x",
+		} {
+			if _, err := ParseUPS(s); err == nil {
+				t.Errorf("ParseUPS(%q): expected an error", s)
+			}
+		}
+	})
+}
+
+func Test_UPSCoordinates_Format(t *testing.T) {
+	c := UPSCoordinates{SouthernHemisphere, 2131976.0314, 1909678.3721}
+
+	if got, want := c.Format(3), "S 2131976.031 1909678.372"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+	if got, want := c.String(), "S 2131976 1909678"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,265 @@
+package nvector
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// utmScaleFactor is the scale factor on the central meridian of each UTM
+	// zone.
+	utmScaleFactor = 0.9996
+	// utmFalseEasting is the easting of the central meridian of each UTM zone.
+	utmFalseEasting = 500000
+	// utmFalseNorthing is the northing of the equator in the southern
+	// hemisphere.
+	utmFalseNorthing = 10000000
+)
+
+// Hemisphere is a hemisphere of the Earth, either north or south of the
+// equator.
+type Hemisphere int
+
+const (
+	// NorthernHemisphere is the hemisphere north of the equator.
+	NorthernHemisphere Hemisphere = iota
+	// SouthernHemisphere is the hemisphere south of the equator.
+	SouthernHemisphere
+)
+
+// String returns "N" for the northern hemisphere, and "S" for the southern
+// hemisphere.
+func (h Hemisphere) String() string {
+	switch h {
+	case NorthernHemisphere:
+		return "N"
+	case SouthernHemisphere:
+		return "S"
+	}
+
+	return "Hemisphere(" + strconv.Itoa(int(h)) + ")"
+}
+
+// UTMCoordinates is a position expressed in the Universal Transverse Mercator
+// (UTM) coordinate system.
+//
+// Easting and Northing are given in meters. In the southern hemisphere, the
+// northing includes a false northing of 10,000km.
+type UTMCoordinates struct {
+	Zone              int
+	Hemisphere        Hemisphere
+	Easting, Northing float64
+}
+
+// ParseUTM parses UTM coordinates from a string.
+//
+// The string must contain the zone, hemisphere, easting, and northing separated
+// by whitespace, e.g. "31 N 448251.795 5411932.678". The zone and hemisphere
+// may also be given without a separator, e.g. "31N 448251 5411932".
+func ParseUTM(s string) (UTMCoordinates, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 3 && len(fields[0]) > 1 {
+		i := len(fields[0]) - 1
+		fields = append([]string{fields[0][:i], fields[0][i:]}, fields[1:]...)
+	}
+	if len(fields) != 4 {
+		return UTMCoordinates{}, fmt.Errorf("invalid UTM coordinates %q", s)
+	}
+
+	zone, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return UTMCoordinates{}, fmt.Errorf("invalid UTM zone %q", fields[0])
+	}
+	if err := checkUTMZone(zone); err != nil {
+		return UTMCoordinates{}, err
+	}
+
+	var h Hemisphere
+	switch strings.ToUpper(fields[1]) {
+	case "N":
+		h = NorthernHemisphere
+	case "S":
+		h = SouthernHemisphere
+	default:
+		return UTMCoordinates{}, fmt.Errorf("invalid UTM hemisphere %q", fields[1])
+	}
+
+	easting, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return UTMCoordinates{}, fmt.Errorf("invalid UTM easting %q", fields[2])
+	}
+	northing, err := strconv.ParseFloat(fields[3], 64)
+	if err != nil {
+		return UTMCoordinates{}, fmt.Errorf("invalid UTM northing %q", fields[3])
+	}
+
+	return UTMCoordinates{zone, h, easting, northing}, nil
+}
+
+// Format formats UTM coordinates as a string, with the easting and northing
+// rounded to the given number of decimal places, e.g. "31 N 448251.795
+// 5411932.678".
+func (c UTMCoordinates) Format(decimals int) string {
+	return fmt.Sprintf(
+		"%d %s %.*f %.*f",
+		c.Zone,
+		c.Hemisphere,
+		decimals,
+		c.Easting,
+		decimals,
+		c.Northing,
+	)
+}
+
+// String formats UTM coordinates as a string, with the easting and northing
+// rounded to the nearest meter.
+func (c UTMCoordinates) String() string {
+	return c.Format(0)
+}
+
+// ToUTM converts an n-vector to UTM coordinates.
+//
+// The zone is selected according to the longitude of the n-vector, including
+// the exceptions for Norway and Svalbard. An error is returned if the n-vector
+// is outside the UTM latitude limits of 80°S to 84°N.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+//
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/utm.js
+func ToUTM(v Vector, e Ellipsoid, f Matrix) (UTMCoordinates, error) {
+	c := ToGeodeticCoordinates(v, f)
+	lat := Degrees(c.Latitude)
+	lon := Degrees(c.Longitude)
+
+	if lat < -80 || lat > 84 {
+		return UTMCoordinates{}, fmt.Errorf(
+			"latitude %v° is outside the UTM limits of 80°S to 84°N",
+			lat,
+		)
+	}
+
+	zone := (int(math.Floor((lon+180)/6)) % 60) + 1
+
+	// Latitude bands are 8° tall, and 0°N is offset 10 bands from the south.
+	band := min(int(math.Floor(lat/8+10)), 19)
+
+	// Norway
+	if zone == 31 && band == 17 && lon >= 3 {
+		zone = 32
+	}
+
+	// Svalbard
+	if band == 19 {
+		switch {
+		case zone == 32 && lon < 9:
+			zone = 31
+		case zone == 32 && lon >= 9:
+			zone = 33
+		case zone == 34 && lon < 21:
+			zone = 33
+		case zone == 34 && lon >= 21:
+			zone = 35
+		case zone == 36 && lon < 33:
+			zone = 35
+		case zone == 36 && lon >= 33:
+			zone = 37
+		}
+	}
+
+	return toUTMZone(v, zone, e, f)
+}
+
+// ToUTMInZone converts an n-vector to UTM coordinates in a specific zone.
+//
+// This can be used to express positions near a zone boundary in the
+// coordinates of a neighbouring zone. An error is returned if the zone is
+// invalid, or if the n-vector is 90° or more of longitude away from the zone's
+// central meridian.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToUTMInZone(
+	v Vector,
+	zone int,
+	e Ellipsoid,
+	f Matrix,
+) (UTMCoordinates, error) {
+	if err := checkUTMZone(zone); err != nil {
+		return UTMCoordinates{}, err
+	}
+
+	return toUTMZone(v, zone, e, f)
+}
+
+// FromUTM converts UTM coordinates to an n-vector.
+//
+// An error is returned if the zone or hemisphere is invalid.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+//
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/utm.js
+func FromUTM(c UTMCoordinates, e Ellipsoid, f Matrix) (Vector, error) {
+	if err := checkUTMZone(c.Zone); err != nil {
+		return Vector{}, err
+	}
+
+	if c.Hemisphere != NorthernHemisphere &&
+		c.Hemisphere != SouthernHemisphere {
+		return Vector{}, fmt.Errorf("invalid UTM hemisphere %v", c.Hemisphere)
+	}
+
+	return utmProjection(e, c.Zone, c.Hemisphere).Inverse(
+		ProjectedCoordinates{c.Easting, c.Northing},
+		f,
+	)
+}
+
+func toUTMZone(
+	v Vector,
+	zone int,
+	e Ellipsoid,
+	f Matrix,
+) (UTMCoordinates, error) {
+	h := NorthernHemisphere
+	if ToGeodeticCoordinates(v, f).Latitude < 0 {
+		h = SouthernHemisphere
+	}
+
+	p, err := utmProjection(e, zone, h).Forward(v, f)
+	if err != nil {
+		return UTMCoordinates{}, fmt.Errorf("UTM zone %d: %w", zone, err)
+	}
+
+	return UTMCoordinates{zone, h, p.Easting, p.Northing}, nil
+}
+
+// utmProjection returns the transverse Mercator projection used for a UTM zone
+// and hemisphere.
+func utmProjection(e Ellipsoid, zone int, h Hemisphere) TransverseMercator {
+	p := TransverseMercator{
+		Ellipsoid:    e,
+		Origin:       GeodeticCoordinates{0, utmCentralMeridian(zone)},
+		ScaleFactor:  utmScaleFactor,
+		FalseEasting: utmFalseEasting,
+	}
+	if h == SouthernHemisphere {
+		p.FalseNorthing = utmFalseNorthing
+	}
+
+	return p
+}
+
+// utmCentralMeridian returns the longitude of the central meridian of a UTM
+// zone in radians.
+func utmCentralMeridian(zone int) float64 {
+	return Radians(float64((zone-1)*6 - 180 + 3))
+}
+
+func checkUTMZone(zone int) error {
+	if zone < 1 || zone > 60 {
+		return fmt.Errorf("invalid UTM zone %d: must be between 1 and 60", zone)
+	}
+
+	return nil
+}
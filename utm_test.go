@@ -0,0 +1,246 @@
+package nvector_test
+
+import (
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_ToUTM(t *testing.T) {
+	tests := map[string]struct {
+		lat, lon float64
+		want     UTMCoordinates
+	}{
+		"origin":         {0, 0, UTMCoordinates{31, NorthernHemisphere, 166021.443, 0}},
+		"Eiffel Tower":   {48.8582, 2.2945, UTMCoordinates{31, NorthernHemisphere, 448251.795, 5411932.678}},
+		"Sydney":         {-33.857, 151.215, UTMCoordinates{56, SouthernHemisphere, 334873.199, 6252266.092}},
+		"White House":    {38.8977, -77.0365, UTMCoordinates{18, NorthernHemisphere, 323394.296, 4307395.634}},
+		"Rio de Janeiro": {-22.9519, -43.2106, UTMCoordinates{23, SouthernHemisphere, 683466.254, 7460687.433}},
+		"Norway":         {60.39135, 5.3249, UTMCoordinates{32, NorthernHemisphere, 297508.410, 6700645.296}},
+		"Svalbard":       {78.2208, 15.6463, UTMCoordinates{33, NorthernHemisphere, 514728.222, 8683092.496}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			got, err := ToUTM(v, WGS84, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Zone != tt.want.Zone {
+				t.Errorf("got zone %d; want %d", got.Zone, tt.want.Zone)
+			}
+			if got.Hemisphere != tt.want.Hemisphere {
+				t.Errorf("got hemisphere %v; want %v", got.Hemisphere, tt.want.Hemisphere)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Easting, tt.want.Easting, 1e-3); !eq {
+				equality.ReportInequality(t, "Easting", ineq)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Northing, tt.want.Northing, 1e-3); !eq {
+				equality.ReportInequality(t, "Northing", ineq)
+			}
+		})
+	}
+
+	t.Run("it applies the Svalbard exceptions", func(t *testing.T) {
+		// the plain 6° zones of these positions differ from the wanted zones
+		tests := map[string]struct {
+			lon  float64
+			want int
+		}{
+			"west of 9°E in zone 32":  {8, 31},
+			"east of 9°E in zone 32":  {10, 33},
+			"west of 21°E in zone 34": {20, 33},
+			"east of 21°E in zone 34": {22, 35},
+			"west of 33°E in zone 36": {32, 35},
+			"east of 33°E in zone 36": {34, 37},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				v := FromGeodeticCoordinates(
+					GeodeticCoordinates{Radians(78), Radians(tt.lon)},
+					ZAxisNorth,
+				)
+
+				got, err := ToUTM(v, WGS84, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got.Zone != tt.want {
+					t.Fatalf("got zone %d; want %d", got.Zone, tt.want)
+				}
+
+				inZone, err := ToUTMInZone(v, tt.want, WGS84, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if inZone != got {
+					t.Errorf("got %+v from ToUTMInZone; want %+v", inZone, got)
+				}
+
+				back, err := FromUTM(inZone, WGS84, ZAxisNorth)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if eq, ineq := equality.EqualToVector(back, v, 1e-12); !eq {
+					equality.ReportInequalities(t, ineq)
+				}
+			})
+		}
+	})
+
+	t.Run("it returns an error outside the UTM latitude limits", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(85), 0},
+			ZAxisNorth,
+		)
+
+		if _, err := ToUTM(v, WGS84, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_ToUTMInZone(t *testing.T) {
+	t.Run("it uses the specified zone", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(60.39135), Radians(5.3249)},
+			ZAxisNorth,
+		)
+
+		got, err := ToUTMInZone(v, 31, WGS84, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Zone != 31 {
+			t.Errorf("got zone %d; want 31", got.Zone)
+		}
+		if got.Easting <= 500000 {
+			t.Errorf("got easting %v; want east of the central meridian", got.Easting)
+		}
+	})
+
+	t.Run("it returns an error for invalid zones", func(t *testing.T) {
+		for _, zone := range []int{-1, 0, 61} {
+			if _, err := ToUTMInZone(Vector{1, 0, 0}, zone, WGS84, XAxisNorth); err == nil {
+				t.Errorf("expected an error for zone %d", zone)
+			}
+		}
+	})
+
+	t.Run("it returns an error far from the central meridian", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{0, Radians(100)},
+			ZAxisNorth,
+		)
+
+		if _, err := ToUTMInZone(v, 31, WGS84, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_FromUTM(t *testing.T) {
+	t.Run("it is the inverse of ToUTM", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			e := rapidgen.Ellipsoid().Draw(t, "ellipsoid")
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			c := GeodeticCoordinates{
+				Latitude:  Radians(rapid.Float64Range(-79.999, 83.999).Draw(t, "latitude")),
+				Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude")),
+			}
+			v := FromGeodeticCoordinates(c, f)
+
+			u, err := ToUTM(v, e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := FromUTM(u, e, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 1e-12 is roughly 6 micrometers on the surface of the Earth
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+
+	t.Run("it returns an error for invalid zones", func(t *testing.T) {
+		for _, zone := range []int{0, 61} {
+			u := UTMCoordinates{zone, NorthernHemisphere, 500000, 0}
+
+			if _, err := FromUTM(u, WGS84, ZAxisNorth); err == nil {
+				t.Errorf("expected an error for zone %d", zone)
+			}
+		}
+	})
+
+	t.Run("it returns an error for invalid hemispheres", func(t *testing.T) {
+		u := UTMCoordinates{31, Hemisphere(2), 500000, 0}
+
+		if _, err := FromUTM(u, WGS84, ZAxisNorth); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func Test_ParseUTM(t *testing.T) {
+	t.Run("it parses UTM coordinates", func(t *testing.T) {
+		for _, s := range []string{
+			"31 N 448251.795 5411932.678",
+			"31N 448251.795 5411932.678",
+			"  31 n  448251.795\t5411932.678 ",
+		} {
+			got, err := ParseUTM(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := UTMCoordinates{31, NorthernHemisphere, 448251.795, 5411932.678}
+			if got != want {
+				t.Errorf("ParseUTM(%q) = %v; want %v", s, got, want)
+			}
+		}
+	})
+
+	t.Run("it returns an error for invalid strings", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"31 N 448251",
+			"61 N 448251 5411932",
+			"31 X 448251 5411932",
+			"31 N east 5411932",
+			"31 N 448251 north",
+		} {
+			if _, err := ParseUTM(s); err == nil {
+				t.Errorf("ParseUTM(%q): expected an error", s)
+			}
+		}
+	})
+}
+
+func Test_UTMCoordinates_Format(t *testing.T) {
+	c := UTMCoordinates{56, SouthernHemisphere, 334873.1994, 6252266.0917}
+
+	if got, want := c.Format(3), "56 S 334873.199 6252266.092"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+	if got, want := c.String(), "56 S 334873 6252266"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,160 @@
+package nvector
+
+import (
+	"errors"
+	"math"
+)
+
+// vincentyInverse returns the length in meters of the geodesic between two
+// geodetic coordinates, and its initial and final azimuths in radians.
+//
+// An error is returned if the solution fails to converge, which can occur for
+// nearly antipodal coordinates.
+//
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-vincenty.js#L159
+func vincentyInverse(
+	p1, p2 GeodeticCoordinates,
+	e Ellipsoid,
+) (s, azimuth1, azimuth2 float64, err error) {
+	a, b, f := e.SemiMajorAxis, e.semiMinorAxis(), e.Flattening
+
+	l := p2.Longitude - p1.Longitude
+	tanU1 := (1 - f) * math.Tan(p1.Latitude)
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	tanU2 := (1 - f) * math.Tan(p2.Latitude)
+	cosU2 := 1 / math.Sqrt(1+tanU2*tanU2)
+	sinU2 := tanU2 * cosU2
+
+	antipodal := math.Abs(l) > math.Pi/2 ||
+		math.Abs(p2.Latitude-p1.Latitude) > math.Pi/2
+
+	lambda := l
+	var sinLambda, cosLambda, sinSqSigma, sinSigma, cosSigma, sigma float64
+	var cosSqAlpha, cos2SigmaM float64
+	converged := false
+
+	for range 1000 {
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSqSigma = math.Pow(cosU2*sinLambda, 2) +
+			math.Pow(cosU1*sinU2-sinU1*cosU2*cosLambda, 2)
+
+		// Co-incident or antipodal points
+		if math.Abs(sinSqSigma) < 1e-24 {
+			converged = true
+			break
+		}
+
+		sinSigma = math.Sqrt(sinSqSigma)
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+
+		// On the equatorial line, cosSqAlpha is 0
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*f*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		check := math.Abs(lambda)
+		if antipodal {
+			check -= math.Pi
+		}
+		if check > math.Pi {
+			return 0, 0, 0, errors.New("geodesic solution failed to converge")
+		}
+
+		if math.Abs(lambda-prev) <= 1e-12 {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return 0, 0, 0, errors.New("geodesic solution failed to converge")
+	}
+
+	if math.Abs(sinSqSigma) < 1e-24 {
+		if sinU1*sinU2+cosU1*cosU2*cosLambda < 0 {
+			return 0, 0, 0, errors.New("geodesic between antipodal points is undefined")
+		}
+
+		return 0, 0, 0, nil
+	}
+
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	aa := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	bb := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := bb * sinSigma * (cos2SigmaM + bb/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bb/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	s = b * aa * (sigma - deltaSigma)
+	azimuth1 = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	azimuth2 = math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+
+	return s, azimuth1, azimuth2, nil
+}
+
+// vincentyDirect returns the geodetic coordinates at the end of a geodesic,
+// given its start, initial azimuth in radians, and length in meters.
+//
+// See: https://github.com/chrisveness/geodesy/blob/761587cd748bd9f7c9825195eba4a9fc5891b859/latlon-ellipsoidal-vincenty.js#L93
+func vincentyDirect(
+	p1 GeodeticCoordinates,
+	azimuth, s float64,
+	e Ellipsoid,
+) GeodeticCoordinates {
+	a, b, f := e.SemiMajorAxis, e.semiMinorAxis(), e.Flattening
+
+	sinAlpha1, cosAlpha1 := math.Sincos(azimuth)
+
+	tanU1 := (1 - f) * math.Tan(p1.Latitude)
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cosSqAlpha := 1 - sinAlpha*sinAlpha
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	aa := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	bb := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+	sigma := s / (b * aa)
+	var sinSigma, cosSigma, cos2SigmaM float64
+
+	for range 100 {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		deltaSigma := bb * sinSigma * (cos2SigmaM + bb/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			bb/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		prev := sigma
+		sigma = s/(b*aa) + deltaSigma
+
+		if math.Abs(sigma-prev) <= 1e-12 {
+			break
+		}
+	}
+
+	sinSigma, cosSigma = math.Sincos(sigma)
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat := math.Atan2(
+		sinU1*cosSigma+cosU1*sinSigma*cosAlpha1,
+		(1-f)*math.Sqrt(sinAlpha*sinAlpha+x*x),
+	)
+	lambda := math.Atan2(
+		sinSigma*sinAlpha1,
+		cosU1*cosSigma-sinU1*sinSigma*cosAlpha1,
+	)
+	c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+	l := lambda - (1-c)*f*sinAlpha*
+		(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	return GeodeticCoordinates{lat, p1.Longitude + l}
+}
@@ -0,0 +1,365 @@
+package nvector
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// webMercatorRadius is the radius of the sphere used by the Web Mercator
+	// projection, equal to the semi-major axis of the WGS84 ellipsoid.
+	webMercatorRadius = 6378137
+	// webMercatorMaxLatitude is the latitude in radians at which the Web
+	// Mercator projection is clipped, making the projected world square. It is
+	// equal to atan(sinh(pi)), or approximately 85.0511°.
+	webMercatorMaxLatitude = 1.4844222297453324
+)
+
+// WebMercator is the spherical Web Mercator projection, also known as
+// Pseudo-Mercator, or EPSG:3857.
+//
+// The geodetic latitude and longitude of each position are projected as if they
+// were on a sphere with the radius of the WGS84 semi-major axis. As a result,
+// the projection is not conformal with respect to the ellipsoid.
+//
+// The projection is defined everywhere, so its methods never return an error.
+//
+// See: https://epsg.io/3857
+type WebMercator struct{}
+
+// Forward converts an n-vector to projected coordinates.
+//
+// Latitudes beyond ±85.0511° are clamped to that latitude, so that all
+// projected coordinates fall within the square world of ±20037508.34m that is
+// used by tiled web maps.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p WebMercator) Forward(v Vector, f Matrix) (ProjectedCoordinates, error) {
+	return webMercatorForward(v, f), nil
+}
+
+// Inverse converts projected coordinates to an n-vector.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p WebMercator) Inverse(c ProjectedCoordinates, f Matrix) (Vector, error) {
+	return webMercatorInverse(c, f), nil
+}
+
+// Distortion returns the point scale factor and meridian convergence of the
+// projection at an n-vector, relative to the sphere used by the projection.
+//
+// Latitudes beyond ±85.0511° are clamped to that latitude.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func (p WebMercator) Distortion(v Vector, f Matrix) (Distortion, error) {
+	lat := ToGeodeticCoordinates(v, f).Latitude
+	lat = math.Max(-webMercatorMaxLatitude, math.Min(webMercatorMaxLatitude, lat))
+	k := 1 / math.Cos(lat)
+
+	return Distortion{k, k, 0}, nil
+}
+
+// webMercatorForward converts an n-vector to Web Mercator coordinates.
+func webMercatorForward(v Vector, f Matrix) ProjectedCoordinates {
+	c := ToGeodeticCoordinates(v, f)
+	lat := math.Max(-webMercatorMaxLatitude, math.Min(webMercatorMaxLatitude, c.Latitude))
+
+	return ProjectedCoordinates{
+		webMercatorRadius * c.Longitude,
+		webMercatorRadius * math.Asinh(math.Tan(lat)),
+	}
+}
+
+// webMercatorInverse converts Web Mercator coordinates to an n-vector.
+func webMercatorInverse(c ProjectedCoordinates, f Matrix) Vector {
+	return FromGeodeticCoordinates(
+		GeodeticCoordinates{
+			math.Atan(math.Sinh(c.Northing / webMercatorRadius)),
+			c.Easting / webMercatorRadius,
+		},
+		f,
+	)
+}
+
+// Tile is a tile of a tiled web map using the XYZ (slippy map) tiling scheme.
+//
+// At each zoom level z, the Web Mercator world is divided into 2^z by 2^z
+// tiles. X increases from west to east starting at 180°W, and Y increases from
+// north to south starting at 85.0511°N.
+type Tile struct {
+	X, Y, Zoom int
+}
+
+// TilePosition is a position within a tile of a tiled web map.
+type TilePosition struct {
+	Tile Tile
+	// X and Y are the offsets in pixels from the top-left corner of the tile.
+	X, Y float64
+}
+
+// ToTile returns the tile that contains an n-vector at a zoom level.
+//
+// Latitudes beyond ±85.0511° are clamped to that latitude.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToTile(v Vector, zoom int, f Matrix) Tile {
+	return ToTilePosition(v, zoom, 1, f).Tile
+}
+
+// ToTilePosition returns the tile that contains an n-vector at a zoom level, and
+// the pixel offset of the n-vector within the tile.
+//
+// tileSize is the width and height of each tile in pixels, typically 256 or
+// 512. Latitudes beyond ±85.0511° are clamped to that latitude.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func ToTilePosition(v Vector, zoom, tileSize int, f Matrix) TilePosition {
+	n := math.Ldexp(1, zoom)
+	u, w := webMercatorUnit(v, f)
+	x := u * n
+	y := w * n
+
+	tx := max(0, min(int(math.Floor(x)), int(n)-1))
+	ty := max(0, min(int(math.Floor(y)), int(n)-1))
+
+	return TilePosition{
+		Tile: Tile{tx, ty, zoom},
+		X:    (x - float64(tx)) * float64(tileSize),
+		Y:    (y - float64(ty)) * float64(tileSize),
+	}
+}
+
+// FromTilePosition converts a position within a tile of a tiled web map to an
+// n-vector.
+//
+// tileSize is the width and height of each tile in pixels, typically 256 or
+// 512.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func FromTilePosition(p TilePosition, tileSize int, f Matrix) Vector {
+	n := math.Ldexp(1, p.Tile.Zoom)
+	u := (float64(p.Tile.X) + p.X/float64(tileSize)) / n
+	w := (float64(p.Tile.Y) + p.Y/float64(tileSize)) / n
+
+	return webMercatorInverse(
+		ProjectedCoordinates{
+			(u - 0.5) * 2 * math.Pi * webMercatorRadius,
+			(0.5 - w) * 2 * math.Pi * webMercatorRadius,
+		},
+		f,
+	)
+}
+
+// TilesCoveringCap returns the tiles that intersect a spherical cap at a zoom
+// level.
+//
+// c is the n-vector at the centre of the cap, and r is the angular radius of
+// the cap in radians (e.g. a distance divided by the radius of the Earth). The
+// tiles are returned in row-major order.
+//
+// An error is returned if the zoom level is not between 0 and 30.
+//
+// f is the coordinate frame in which the n-vector is decomposed.
+func TilesCoveringCap(c Vector, r float64, zoom int, f Matrix) ([]Tile, error) {
+	if err := checkTileZoom(zoom); err != nil {
+		return nil, err
+	}
+
+	gc := ToGeodeticCoordinates(c, f)
+	n := int(math.Ldexp(1, zoom))
+
+	// Find the range of tile rows that the cap's latitudes span:
+	y0 := ToTile(FromGeodeticCoordinates(
+		GeodeticCoordinates{math.Min(gc.Latitude+r, math.Pi/2), gc.Longitude},
+		XAxisNorth,
+	), zoom, XAxisNorth).Y
+	y1 := ToTile(FromGeodeticCoordinates(
+		GeodeticCoordinates{math.Max(gc.Latitude-r, -math.Pi/2), gc.Longitude},
+		XAxisNorth,
+	), zoom, XAxisNorth).Y
+
+	// Find the range of tile columns that the cap's longitudes span. If the cap
+	// contains a pole, it spans all longitudes:
+	x0, x1 := 0, n-1
+	if s := math.Sin(r) / math.Cos(gc.Latitude); gc.Latitude+r < math.Pi/2 &&
+		gc.Latitude-r > -math.Pi/2 && s < 1 {
+		dLon := math.Asin(s)
+		x0 = tileX(gc.Longitude-dLon, n)
+		x1 = tileX(gc.Longitude+dLon, n)
+		if x1 < x0 {
+			// The cap crosses the antimeridian
+			x1 += n
+		}
+	}
+
+	var tiles []Tile
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1 && x-x0 < n; x++ {
+			t := Tile{x % n, y, zoom}
+
+			if tileDistance(t, gc) <= r {
+				tiles = append(tiles, t)
+			}
+		}
+	}
+
+	return tiles, nil
+}
+
+// TilesCoveringPolyline returns the tiles that intersect a polyline at a zoom
+// level.
+//
+// vs are the n-vectors at the vertices of the polyline, which are joined by
+// great circle arcs. The arc between antipodal vertices is undefined, so only
+// the tiles containing such vertices are included. The tiles are returned in
+// the order that they are first visited along the polyline.
+//
+// An error is returned if the zoom level is not between 0 and 30.
+//
+// f is the coordinate frame in which the n-vectors are decomposed.
+func TilesCoveringPolyline(vs []Vector, zoom int, f Matrix) ([]Tile, error) {
+	if err := checkTileZoom(zoom); err != nil {
+		return nil, err
+	}
+
+	var tiles []Tile
+	seen := map[Tile]bool{}
+	visit := func(t Tile) {
+		if !seen[t] {
+			seen[t] = true
+			tiles = append(tiles, t)
+		}
+	}
+
+	for i, v := range vs {
+		a := v.Transform(f)
+		ta := ToTile(a, zoom, XAxisNorth)
+		visit(ta)
+
+		if i+1 < len(vs) {
+			b := vs[i+1].Transform(f)
+			tilesCoveringArc(a, b, ta, ToTile(b, zoom, XAxisNorth), 0, visit)
+		}
+	}
+
+	return tiles, nil
+}
+
+// tilesCoveringArc visits the tiles that intersect the great circle arc between
+// a and b, by bisecting the arc until its end points are in the same or
+// neighbouring tiles.
+//
+// Because great circle arcs are curved in the Web Mercator projection, the arc
+// is also bisected until it is short compared to the size of the tiles, so that
+// its deviation from a straight line between the end points is negligible.
+func tilesCoveringArc(a, b Vector, ta, tb Tile, depth int, visit func(Tile)) {
+	n := int(math.Ldexp(1, ta.Zoom))
+	dx := (tb.X - ta.X + n) % n
+	dx = min(dx, n-dx)
+	dy := tb.Y - ta.Y
+	if dy < 0 {
+		dy = -dy
+	}
+
+	lat := math.Min(
+		webMercatorMaxLatitude,
+		math.Max(math.Abs(math.Asin(a.X)), math.Abs(math.Asin(b.X))),
+	)
+	tileSize := 2 * math.Pi * math.Cos(lat) / float64(n)
+	length := math.Atan2(a.Cross(b).Norm(), a.Dot(b))
+
+	// 52 bisections is enough to reduce any arc below float64 precision
+	if (dx+dy <= 1 && length <= tileSize/8) || depth >= 52 {
+		visit(tb)
+		return
+	}
+
+	// The arc between antipodal end points is undefined
+	sum := a.Add(b)
+	if sum.Norm() == 0 {
+		visit(tb)
+		return
+	}
+
+	m := sum.Normalize()
+	tm := ToTile(m, ta.Zoom, XAxisNorth)
+	tilesCoveringArc(a, m, ta, tm, depth+1, visit)
+	tilesCoveringArc(m, b, tm, tb, depth+1, visit)
+}
+
+// tileDistance returns the minimum angular distance in radians between
+// geodetic coordinates and a tile, treating the Earth as a sphere.
+//
+// Consistent with the latitude clamping of ToTile, the tiles in the top and
+// bottom rows are treated as extending to the poles.
+func tileDistance(t Tile, c GeodeticCoordinates) float64 {
+	n := math.Ldexp(1, t.Zoom)
+	lon0 := float64(t.X)/n*2*math.Pi - math.Pi
+	lon1 := float64(t.X+1)/n*2*math.Pi - math.Pi
+	lat0 := -math.Pi / 2
+	if t.Y+1 < int(n) {
+		lat0 = math.Atan(math.Sinh(math.Pi * (1 - 2*float64(t.Y+1)/n)))
+	}
+	lat1 := math.Pi / 2
+	if t.Y > 0 {
+		lat1 = math.Atan(math.Sinh(math.Pi * (1 - 2*float64(t.Y)/n)))
+	}
+
+	p := FromGeodeticCoordinates(c, XAxisNorth)
+	distance := func(lat, lon float64) float64 {
+		q := FromGeodeticCoordinates(GeodeticCoordinates{lat, lon}, XAxisNorth)
+
+		return math.Atan2(p.Cross(q).Norm(), p.Dot(q))
+	}
+	clampLat := func(lat float64) float64 {
+		return math.Max(lat0, math.Min(lat1, lat))
+	}
+
+	// If the coordinates are within the tile's longitudes, the closest point is
+	// on the same meridian:
+	if dLon := math.Mod(c.Longitude-lon0+4*math.Pi, 2*math.Pi); dLon <= lon1-lon0 {
+		return distance(clampLat(c.Latitude), c.Longitude)
+	}
+
+	// Otherwise, the closest point is on one of the tile's bounding meridians,
+	// either at the point on the meridian's great circle closest to the
+	// coordinates, or at one of the tile's corners:
+	d := math.Inf(1)
+	for _, lon := range []float64{lon0, lon1} {
+		sLat, cLat := math.Sincos(c.Latitude)
+		closest := math.Atan2(sLat, cLat*math.Cos(c.Longitude-lon))
+
+		for _, lat := range []float64{clampLat(closest), lat0, lat1} {
+			d = math.Min(d, distance(lat, lon))
+		}
+	}
+
+	return d
+}
+
+// checkTileZoom returns an error if a zoom level is outside the range supported
+// when covering areas with tiles, so that the tile counts fit in an int.
+func checkTileZoom(zoom int) error {
+	if zoom < 0 || zoom > 30 {
+		return fmt.Errorf("invalid zoom level %d: must be between 0 and 30", zoom)
+	}
+
+	return nil
+}
+
+// tileX returns the column of the tile that contains a longitude.
+func tileX(lon float64, n int) int {
+	u := math.Remainder(lon, 2*math.Pi)/(2*math.Pi) + 0.5
+
+	return max(0, min(int(math.Floor(u*float64(n))), n-1))
+}
+
+// webMercatorUnit returns the Web Mercator coordinates of an n-vector, scaled
+// so that the projected world is a unit square with its origin in the top-left
+// corner.
+func webMercatorUnit(v Vector, f Matrix) (u, w float64) {
+	c := webMercatorForward(v, f)
+	circumference := 2 * math.Pi * webMercatorRadius
+
+	return c.Easting/circumference + 0.5, 0.5 - c.Northing/circumference
+}
@@ -0,0 +1,330 @@
+package nvector_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	. "github.com/ezzatron/nvector-go"
+	"github.com/ezzatron/nvector-go/internal/equality"
+	"github.com/ezzatron/nvector-go/internal/rapidgen"
+	"pgregory.net/rapid"
+)
+
+func Test_WebMercator_Forward(t *testing.T) {
+	t.Run("it matches the EPSG worked example", func(t *testing.T) {
+		v := FromGeodeticCoordinates(
+			GeodeticCoordinates{
+				Radians(24 + 22.0/60 + 54.433/3600),
+				Radians(-100 - 20.0/60),
+			},
+			ZAxisNorth,
+		)
+
+		got, err := WebMercator{}.Forward(v, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if eq, ineq := equality.EqualToFloat64(got.Easting, -11169055.58, 1e-2); !eq {
+			equality.ReportInequality(t, "Easting", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Northing, 2800000.00, 1e-2); !eq {
+			equality.ReportInequality(t, "Northing", ineq)
+		}
+	})
+
+	t.Run("it clamps latitudes beyond 85.0511°", func(t *testing.T) {
+		for _, lat := range []float64{85.06, 89, 90} {
+			north := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(lat), 0},
+				ZAxisNorth,
+			)
+			south := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(-lat), 0},
+				ZAxisNorth,
+			)
+			want := math.Pi * 6378137
+
+			got, err := WebMercator{}.Forward(north, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Northing, want, 1e-6); !eq {
+				equality.ReportInequality(t, "Northing", ineq)
+			}
+
+			got, err = WebMercator{}.Forward(south, ZAxisNorth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eq, ineq := equality.EqualToFloat64(got.Northing, -want, 1e-6); !eq {
+				equality.ReportInequality(t, "Northing", ineq)
+			}
+		}
+	})
+}
+
+func Test_WebMercator_Inverse(t *testing.T) {
+	t.Run("it is the inverse of Forward", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{
+					Latitude:  Radians(rapid.Float64Range(-85, 85).Draw(t, "latitude")),
+					Longitude: Radians(rapid.Float64Range(-180, 180).Draw(t, "longitude")),
+				},
+				f,
+			)
+
+			c, err := WebMercator{}.Forward(v, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := WebMercator{}.Inverse(c, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-14); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_WebMercator_Distortion(t *testing.T) {
+	v := FromGeodeticCoordinates(
+		GeodeticCoordinates{Radians(60), Radians(10)},
+		ZAxisNorth,
+	)
+
+	got, err := WebMercator{}.Distortion(v, ZAxisNorth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if eq, ineq := equality.EqualToFloat64(got.MeridianScale, 2, 1e-15); !eq {
+		equality.ReportInequality(t, "MeridianScale", ineq)
+	}
+	if eq, ineq := equality.EqualToFloat64(got.ParallelScale, 2, 1e-15); !eq {
+		equality.ReportInequality(t, "ParallelScale", ineq)
+	}
+	if eq, ineq := equality.EqualToRadians(got.Convergence, 0, 0); !eq {
+		equality.ReportInequality(t, "Convergence", ineq)
+	}
+}
+
+func Test_ToTile(t *testing.T) {
+	tests := map[string]struct {
+		lat, lon float64
+		zoom     int
+		want     Tile
+	}{
+		"zoom 0":          {51.5, -0.12, 0, Tile{0, 0, 0}},
+		"London":          {51.5, -0.12, 10, Tile{511, 340, 10}},
+		"Sydney":          {-33.857, 151.215, 12, Tile{3768, 2457, 12}},
+		"north-west edge": {90, -180, 4, Tile{0, 0, 4}},
+		"south-east edge": {-90, 180, 4, Tile{15, 15, 4}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{Radians(tt.lat), Radians(tt.lon)},
+				ZAxisNorth,
+			)
+
+			if got := ToTile(v, tt.zoom, ZAxisNorth); got != tt.want {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ToTilePosition(t *testing.T) {
+	t.Run("it returns the pixel offset within the tile", func(t *testing.T) {
+		v := FromGeodeticCoordinates(GeodeticCoordinates{0, 0}, ZAxisNorth)
+
+		got := ToTilePosition(v, 1, 256, ZAxisNorth)
+
+		if want := (Tile{1, 1, 1}); got.Tile != want {
+			t.Errorf("got tile %v; want %v", got.Tile, want)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.X, 0, 1e-9); !eq {
+			equality.ReportInequality(t, "X", ineq)
+		}
+		if eq, ineq := equality.EqualToFloat64(got.Y, 0, 1e-9); !eq {
+			equality.ReportInequality(t, "Y", ineq)
+		}
+	})
+}
+
+func Test_FromTilePosition(t *testing.T) {
+	t.Run("it is the inverse of ToTilePosition", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			zoom := rapid.IntRange(0, 22).Draw(t, "zoom")
+			tileSize := rapid.SampledFrom([]int{256, 512}).Draw(t, "tileSize")
+			v := FromGeodeticCoordinates(
+				GeodeticCoordinates{
+					Latitude:  Radians(rapid.Float64Range(-85, 85).Draw(t, "latitude")),
+					Longitude: Radians(rapid.Float64Range(-179.9, 179.9).Draw(t, "longitude")),
+				},
+				f,
+			)
+
+			got := FromTilePosition(ToTilePosition(v, zoom, tileSize, f), tileSize, f)
+
+			if eq, ineq := equality.EqualToVector(got, v, 1e-12); !eq {
+				equality.ReportInequalities(t, ineq)
+			}
+		})
+	})
+}
+
+func Test_TilesCoveringCap(t *testing.T) {
+	t.Run("it returns a single tile for a small cap", func(t *testing.T) {
+		c := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(-33.857), Radians(151.215)},
+			ZAxisNorth,
+		)
+
+		got, err := TilesCoveringCap(c, 100/6371e3, 12, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Tile{{3768, 2457, 12}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it returns neighbouring tiles that intersect the cap", func(t *testing.T) {
+		c := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(51.5), Radians(-0.12)},
+			ZAxisNorth,
+		)
+
+		got, err := TilesCoveringCap(c, 10e3/6371e3, 10, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Tile{{511, 340, 10}, {512, 340, 10}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it handles caps that cross the antimeridian", func(t *testing.T) {
+		c := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(1), Radians(179.9)},
+			ZAxisNorth,
+		)
+
+		got, err := TilesCoveringCap(c, Radians(0.5), 3, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Tile{{7, 3, 3}, {0, 3, 3}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it handles caps that contain a pole", func(t *testing.T) {
+		c := FromGeodeticCoordinates(
+			GeodeticCoordinates{Radians(89), 0},
+			ZAxisNorth,
+		)
+
+		got, err := TilesCoveringCap(c, Radians(3), 2, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Tile{{0, 0, 2}, {1, 0, 2}, {2, 0, 2}, {3, 0, 2}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it returns an error for invalid zoom levels", func(t *testing.T) {
+		for _, zoom := range []int{-1, 31, 63, 64} {
+			if _, err := TilesCoveringCap(Vector{X: 1}, 0.1, zoom, XAxisNorth); err == nil {
+				t.Errorf("expected an error for zoom %d", zoom)
+			}
+		}
+	})
+}
+
+func Test_TilesCoveringPolyline(t *testing.T) {
+	t.Run("it handles polylines that cross the antimeridian", func(t *testing.T) {
+		vs := []Vector{
+			FromGeodeticCoordinates(GeodeticCoordinates{0, Radians(179)}, ZAxisNorth),
+			FromGeodeticCoordinates(GeodeticCoordinates{0, Radians(-179)}, ZAxisNorth),
+		}
+
+		got, err := TilesCoveringPolyline(vs, 3, ZAxisNorth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Tile{{7, 4, 3}, {0, 4, 3}}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	})
+
+	t.Run("it includes every point along the polyline", func(t *testing.T) {
+		rapid.Check(t, func(t *rapid.T) {
+			f := rapidgen.RotationMatrix().Draw(t, "coordFrame")
+			zoom := rapid.IntRange(0, 10).Draw(t, "zoom")
+			vs := rapid.SliceOfN(rapidgen.UnitVector(), 2, 4).Draw(t, "vertices")
+			for i := range vs {
+				vs[i] = vs[i].Transform(f.Transpose())
+			}
+
+			tiles, err := TilesCoveringPolyline(vs, zoom, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i+1 < len(vs); i++ {
+				a, b := vs[i], vs[i+1]
+				if a.Add(b).Norm() < 1e-3 {
+					// skip nearly antipodal end points, where the arc is undefined
+					continue
+				}
+
+				for j := 0; j <= 1000; j++ {
+					s := float64(j) / 1000
+					p := a.Scale(1 - s).Add(b.Scale(s)).Normalize()
+
+					// skip points on tile boundaries, where the tile is ambiguous
+					tp := ToTilePosition(p, zoom, 1, f)
+					if math.Min(tp.X, 1-tp.X) < 1e-9 || math.Min(tp.Y, 1-tp.Y) < 1e-9 {
+						continue
+					}
+
+					if want := ToTile(p, zoom, f); !slices.Contains(tiles, want) {
+						t.Fatalf("missing tile %v at point %v of segment %v", want, s, i)
+					}
+				}
+			}
+		})
+	})
+
+	t.Run("it returns an error for invalid zoom levels", func(t *testing.T) {
+		vs := []Vector{{X: 1}, {Y: 1}}
+
+		for _, zoom := range []int{-1, 31, 63, 64} {
+			if _, err := TilesCoveringPolyline(vs, zoom, XAxisNorth); err == nil {
+				t.Errorf("expected an error for zoom %d", zoom)
+			}
+		}
+	})
+}